@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kusha/chores-md/internal/model"
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// summary collects the archived completions of one chore in one file.
+type summary struct {
+	name  string
+	count int
+	last  model.Completion
+}
+
+// ArchiveLogCmd moves the completion entries dated before the given day out
+// of the log and into archive/<year>.md next to the chores file. Each chore
+// keeps one "DATE Name [archived N]" line in the file its entries came from,
+// dated like its last archived completion, so its schedule and count are
+// unchanged. Summaries from earlier runs are folded into the new ones, and
+// partial completions are archived without being counted.
+func ArchiveLogCmd(file string, before time.Time, out io.Writer) error {
+	result, unlock, err := parseForUpdate(file, out)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	names := make(map[string]string)
+	for _, chore := range result.Chores {
+		names[strings.ToLower(chore.Name)] = chore.Name
+	}
+
+	old := make(map[string][]model.Completion)
+	count := 0
+	for _, c := range result.Completions {
+		if c.Date.Before(before) {
+			old[c.Source] = append(old[c.Source], c)
+			if c.Archived == 0 {
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		fmt.Fprintf(out, "Nothing to archive before %s\n", before.Format("2006-01-02"))
+		return nil
+	}
+
+	dir := archiveDir(file)
+	archived := make(map[int][]string)
+	for _, src := range result.Sources {
+		if len(old[src.Path]) == 0 {
+			continue
+		}
+		content, err := readSource(result, src.Path)
+		if err != nil {
+			return err
+		}
+		lines, moved := compactLog(string(content), old[src.Path], names)
+		for year, entries := range moved {
+			archived[year] = append(archived[year], entries...)
+		}
+
+		// The archive is written before the entries are removed from the
+		// log, so an interrupted run leaves duplicates rather than gaps.
+		if err := writeArchive(result, dir, moved); err != nil {
+			return err
+		}
+		if err := writeAtomic(src.Path, []byte(lines)); err != nil {
+			return err
+		}
+	}
+
+	years := make([]int, 0, len(archived))
+	for year := range archived {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	for _, year := range years {
+		fmt.Fprintf(out, "Archived: %d entries to %s\n", len(archived[year]), archiveFile(dir, year))
+	}
+	commitUpdate(file, result, fmt.Sprintf("archive-log: %d entries before %s", count, before.Format("2006-01-02")), out)
+	return nil
+}
+
+// compactLog removes the given entries from content and puts one summary line
+// per chore where the first of them was. It returns the new content and the
+// removed lines by year, except for earlier summaries whose entries are
+// already archived.
+func compactLog(content string, entries []model.Completion, names map[string]string) (string, map[int][]string) {
+	eol := "\n"
+	if strings.Contains(content, "\r\n") {
+		eol = "\r\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	remove := make(map[int]bool)
+	first := len(lines)
+	moved := make(map[int][]string)
+	summaries := make(map[string]*summary)
+	for _, c := range entries {
+		idx := c.Line - 1
+		remove[idx] = true
+		first = min(first, idx)
+
+		key := strings.ToLower(c.ChoreName)
+		s, ok := summaries[key]
+		if !ok {
+			name, known := names[key]
+			if !known {
+				name = strings.TrimSpace(c.ChoreName)
+			}
+			s = &summary{name: name}
+			summaries[key] = s
+		}
+
+		if c.Archived == 0 {
+			moved[c.Date.Year()] = append(moved[c.Date.Year()], strings.TrimSpace(lines[idx]))
+		}
+		if len(c.Steps) > 0 {
+			continue
+		}
+		s.count += max(c.Archived, 1)
+		if !c.Date.Before(s.last.Date) {
+			s.last = c
+		}
+	}
+
+	var summaryLines []string
+	var sorted []*summary
+	for _, s := range summaries {
+		if s.count > 0 {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].last.Date.Equal(sorted[j].last.Date) {
+			return sorted[i].last.Date.Before(sorted[j].last.Date)
+		}
+		return strings.ToLower(sorted[i].name) < strings.ToLower(sorted[j].name)
+	})
+	for _, s := range sorted {
+		dateStr := s.last.Date.Format("2006-01-02")
+		if s.last.HasTime {
+			dateStr = s.last.Date.Format("2006-01-02 15:04")
+		}
+		summaryLines = append(summaryLines, fmt.Sprintf("%s %s [archived %d]", dateStr, s.name, s.count))
+	}
+
+	var out []string
+	for i, line := range lines {
+		if i == first {
+			out = append(out, summaryLines...)
+		}
+		if !remove[i] {
+			out = append(out, line)
+		}
+	}
+	return strings.Join(out, eol), moved
+}
+
+// writeArchive appends archived entries to the yearly files in dir, creating
+// them as needed.
+func writeArchive(result *parser.ParseResult, dir string, moved map[int][]string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for year, entries := range moved {
+		path := archiveFile(dir, year)
+		text := strings.Join(entries, "\n")
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			header := fmt.Sprintf("# Archive %d\n\n", year)
+			if err := writeAtomic(path, []byte(header+text+"\n")); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := appendEntry(result, path, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveDir returns the directory archived entries go to: "archive" next to
+// the chores file, or inside the chores directory. Being a subdirectory, it
+// is not read along with the chores.
+func archiveDir(file string) string {
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		return filepath.Join(file, "archive")
+	}
+	return filepath.Join(filepath.Dir(file), "archive")
+}
+
+func archiveFile(dir string, year int) string {
+	return filepath.Join(dir, fmt.Sprintf("%d.md", year))
+}
+
+// archivedCompletions returns the completions in the archive of the chores
+// file, or none if nothing has been archived.
+func archivedCompletions(file string) ([]model.Completion, error) {
+	dir := archiveDir(file)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	result, err := parser.ParseFile(dir)
+	if err != nil {
+		return nil, err
+	}
+	return result.Completions, nil
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestArchiveLogCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+
+	content := `## Trash
+> 1w
+
+## Bathroom
+> 1w
+
+- [ ] Scrub toilet
+- [ ] Mop floor
+
+2023-12-25 Trash
+2024-06-03 trash @bob
+2024-06-04 Bathroom [steps 1]
+2024-06-10 18:30 Bathroom
+2025-01-06 Trash
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := ArchiveLogCmd(testFile, before, &buf); err != nil {
+		t.Fatalf("ArchiveLogCmd error: %v", err)
+	}
+
+	want := `## Trash
+> 1w
+
+## Bathroom
+> 1w
+
+- [ ] Scrub toilet
+- [ ] Mop floor
+
+2024-06-03 Trash [archived 2]
+2024-06-10 18:30 Bathroom [archived 1]
+2025-01-06 Trash
+`
+	got, _ := os.ReadFile(testFile)
+	if string(got) != want {
+		t.Errorf("chores file =\n%s\nwant\n%s", got, want)
+	}
+
+	archive, _ := os.ReadFile(filepath.Join(tmpDir, "archive", "2024.md"))
+	wantArchive := "# Archive 2024\n\n2024-06-03 trash @bob\n2024-06-04 Bathroom [steps 1]\n2024-06-10 18:30 Bathroom\n"
+	if string(archive) != wantArchive {
+		t.Errorf("archive/2024.md =\n%s\nwant\n%s", archive, wantArchive)
+	}
+	if !strings.Contains(buf.String(), "Archived: 1 entries to "+filepath.Join(tmpDir, "archive", "2023.md")) ||
+		!strings.Contains(buf.String(), "Archived: 3 entries to "+filepath.Join(tmpDir, "archive", "2024.md")) {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	// A second run folds the earlier summaries into the new ones without
+	// archiving them again.
+	buf.Reset()
+	if err := ArchiveLogCmd(testFile, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), &buf); err != nil {
+		t.Fatalf("ArchiveLogCmd error: %v", err)
+	}
+	got, _ = os.ReadFile(testFile)
+	if !strings.HasSuffix(string(got), "2024-06-10 18:30 Bathroom [archived 1]\n2025-01-06 Trash [archived 3]\n") {
+		t.Errorf("chores file after second run =\n%s", got)
+	}
+	archive, _ = os.ReadFile(filepath.Join(tmpDir, "archive", "2025.md"))
+	if string(archive) != "# Archive 2025\n\n2025-01-06 Trash\n" {
+		t.Errorf("archive/2025.md =\n%s", archive)
+	}
+
+	buf.Reset()
+	if err := StatsWithOptions(testFile, StatsOptions{}, &buf); err != nil {
+		t.Fatalf("StatsWithOptions error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Trash\t3 done\t0 on time\t0 in grace\t0 late") {
+		t.Errorf("stats = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := StatsWithOptions(testFile, StatsOptions{Archives: true}, &buf); err != nil {
+		t.Fatalf("StatsWithOptions error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Trash\t3 done\t0 on time\t0 in grace\t2 late") {
+		t.Errorf("stats with archives = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := ArchiveLogCmd(testFile, before, &buf); err != nil {
+		t.Fatalf("ArchiveLogCmd error: %v", err)
+	}
+	if buf.String() != "Nothing to archive before 2025-01-01\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+}
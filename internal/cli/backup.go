@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kusha/chores-md/internal/config"
+)
+
+// snapshotTime is the layout of the time that starts a snapshot ID, which
+// sorts in time order.
+const snapshotTime = "20060102-150405.000"
+
+// snapshot is a backup of a file taken before a command changed it. Its ID
+// is the time it was taken followed by the file name, as in
+// "20260204-153012.123-chores.md".
+type snapshot struct {
+	ID   string
+	File string // The file it is a backup of
+	Path string // Where the backup is stored
+	Time time.Time
+}
+
+// backupSettings returns how many backups to keep of each file and the
+// directory holding them, from the backups and backup-dir settings.
+func backupSettings() (int, string, error) {
+	keep, err := setting("backups", nil)
+	if err != nil {
+		return 0, "", err
+	}
+	n, err := strconv.Atoi(keep)
+	if err != nil || n < 0 {
+		return 0, "", fmt.Errorf("invalid value %q for backups (expected a number)", keep)
+	}
+
+	dir, err := pathSetting("backup-dir")
+	if err != nil {
+		return 0, "", err
+	}
+	if dir == "" {
+		if dir, err = config.DefaultBackupDir(); err != nil {
+			return 0, "", err
+		}
+	}
+	return n, dir, nil
+}
+
+// backupDir returns the directory holding the backups of file: one per file,
+// named after it and a hash of its absolute path.
+func backupDir(root string, file string) (string, error) {
+	if target, err := filepath.EvalSymlinks(file); err == nil {
+		file = target
+	}
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(root, fmt.Sprintf("%s-%x", filepath.Base(abs), sum[:4])), nil
+}
+
+// backup saves the current content of file, if it exists, before it is
+// replaced, and removes the oldest backups beyond the number to keep.
+func backup(file string) error {
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	keep, root, err := backupSettings()
+	if err != nil || keep == 0 {
+		return err
+	}
+	dir, err := backupDir(root, file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	// Backups taken within the same millisecond get the next free one.
+	now := time.Now()
+	for {
+		id := now.Format(snapshotTime) + "-" + filepath.Base(file)
+		f, err := os.OpenFile(filepath.Join(dir, id), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if os.IsExist(err) {
+			now = now.Add(time.Millisecond)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(content)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		break
+	}
+
+	snapshots, err := listSnapshots(root, file)
+	if err != nil {
+		return err
+	}
+	for i := keep; i < len(snapshots); i++ {
+		if err := os.Remove(snapshots[i].Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listSnapshots returns the backups of file, newest first.
+func listSnapshots(root string, file string) ([]snapshot, error) {
+	dir, err := backupDir(root, file)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []snapshot
+	for _, entry := range entries {
+		name := entry.Name()
+		if len(name) <= len(snapshotTime) {
+			continue
+		}
+		t, err := time.ParseInLocation(snapshotTime, name[:len(snapshotTime)], time.Local)
+		if err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot{ID: name, File: file, Path: filepath.Join(dir, name), Time: t})
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].ID > snapshots[j].ID })
+	return snapshots, nil
+}
+
+// findSnapshot looks up a snapshot by ID, or by a unique prefix of it, among
+// the backups of files.
+func findSnapshot(root string, files []string, id string) (snapshot, error) {
+	var found []snapshot
+	for _, file := range files {
+		snapshots, err := listSnapshots(root, file)
+		if err != nil {
+			return snapshot{}, err
+		}
+		for _, s := range snapshots {
+			if s.ID == id {
+				return s, nil
+			}
+			if strings.HasPrefix(s.ID, id) {
+				found = append(found, s)
+			}
+		}
+	}
+	switch len(found) {
+	case 0:
+		return snapshot{}, fmt.Errorf("snapshot not found: %q (see chores restore --list)", id)
+	case 1:
+		return found[0], nil
+	default:
+		return snapshot{}, fmt.Errorf("snapshot %q is ambiguous: %d snapshots match", id, len(found))
+	}
+}
@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/kusha/chores-md/internal/model"
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// BackfillOptions configures BackfillAuthorsCmd.
+type BackfillOptions struct {
+	// Authors maps commit author names or emails to the names used in
+	// @person annotations. Other authors go by their first name in lower
+	// case.
+	Authors map[string]string
+}
+
+// entryCommit is the commit that added a log entry.
+type entryCommit struct {
+	Hash   string
+	Author string
+	Email  string
+}
+
+// BlameCmd lists the completions in the chores, each with the commit that
+// added it and its author, from the git history of the files. An entry is
+// recognized by its date, time and chore name, so adding annotations or
+// formatting it later does not change where it came from.
+func BlameCmd(file string, out io.Writer) error {
+	result, err := parser.ParseFile(file)
+	if err != nil {
+		return err
+	}
+	warnConflicts(result, out)
+	commits, err := entryCommits(file, result)
+	if err != nil {
+		return err
+	}
+	lines, err := sourceLines(result)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range result.Completions {
+		line := strings.TrimSpace(lines[c.Source][c.Line-1])
+		if commit, ok := commits.take(c); ok {
+			fmt.Fprintf(out, "%s\t%s\t%s\n", commit.Hash[:8], commit.Author, line)
+		} else {
+			fmt.Fprintf(out, "-\tnot committed\t%s\n", line)
+		}
+	}
+	return nil
+}
+
+// BackfillAuthorsCmd adds an @person annotation to each completion that has
+// none, naming the author of the commit that added it (see BlameCmd).
+func BackfillAuthorsCmd(file string, opts BackfillOptions, out io.Writer) error {
+	result, unlock, err := parseForUpdate(file, out)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	commits, err := entryCommits(file, result)
+	if err != nil {
+		return err
+	}
+
+	changed := make(map[string]map[int]string)
+	count := 0
+	for _, c := range result.Completions {
+		commit, ok := commits.take(c)
+		if !ok || c.Person != "" || c.Archived > 0 {
+			continue
+		}
+		person := personName(commit, opts.Authors)
+		if person == "" {
+			continue
+		}
+		if changed[c.Source] == nil {
+			changed[c.Source] = make(map[int]string)
+		}
+		changed[c.Source][c.Line-1] = person
+		count++
+	}
+	if count == 0 {
+		fmt.Fprintln(out, "No entries to backfill")
+		return nil
+	}
+
+	count = 0
+	for _, src := range result.Sources {
+		if len(changed[src.Path]) == 0 {
+			continue
+		}
+		content, err := readSource(result, src.Path)
+		if err != nil {
+			return err
+		}
+		lines := strings.Split(string(content), "\n")
+		for idx, person := range changed[src.Path] {
+			if line, ok := withPerson(lines[idx], person, result.Chores); ok {
+				lines[idx] = line
+				count++
+			}
+		}
+		if err := writeAtomic(src.Path, []byte(strings.Join(lines, "\n"))); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(out, "Backfilled: %d entries\n", count)
+	commitUpdate(file, result, fmt.Sprintf("backfill-authors: %d entries", count), out)
+	return nil
+}
+
+// entryCommitQueue holds the commits that added log entries, oldest first,
+// by entryID.
+type entryCommitQueue map[string][]entryCommit
+
+// take returns the commit that added the completion c, and removes it so
+// that an identical entry added later gets its own commit.
+func (q entryCommitQueue) take(c model.Completion) (entryCommit, bool) {
+	id := entryID(c)
+	if len(q[id]) == 0 {
+		return entryCommit{}, false
+	}
+	commit := q[id][0]
+	q[id] = q[id][1:]
+	return commit, true
+}
+
+// entryID identifies a completion across edits: its date, time and chore.
+func entryID(c model.Completion) string {
+	return fmt.Sprintf("%s %t %s", c.Date.Format("2006-01-02 15:04"), c.HasTime, strings.ToLower(c.ChoreName))
+}
+
+// entryCommits walks the git history of the files the chores are read
+// from, and of the archive, and returns the commits that added completions.
+func entryCommits(file string, result *parser.ParseResult) (entryCommitQueue, error) {
+	dir := choresDir(file)
+	args := []string{"log", "--reverse", "--no-color", "--no-ext-diff", "--unified=0", "-p", "--format=%x01%H%x00%an%x00%ae", "--"}
+	args = append(args, gitPaths(dir, append(sourcePaths(result), archiveDir(file)))...)
+	log, err := runGit(dir, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := make(entryCommitQueue)
+	var commit entryCommit
+	var added []string
+	flush := func() {
+		if len(added) == 0 {
+			return
+		}
+		// Lines of a diff parse on their own, as entries outside a log
+		// section are read unless the file is strict.
+		if parsed, err := parser.Parse(strings.Join(added, "\n")); err == nil {
+			parser.ResolvePersons(parsed.Completions, result.Chores)
+			for _, c := range parsed.Completions {
+				id := entryID(c)
+				queue[id] = append(queue[id], commit)
+			}
+		}
+		added = added[:0]
+	}
+
+	inHunk := false
+	for _, line := range strings.Split(log, "\n") {
+		switch {
+		case strings.HasPrefix(line, "\x01"):
+			flush()
+			fields := strings.SplitN(line[1:], "\x00", 3)
+			if len(fields) == 3 {
+				commit = entryCommit{Hash: fields[0], Author: fields[1], Email: fields[2]}
+			}
+			inHunk = false
+		case strings.HasPrefix(line, "diff "):
+			inHunk = false
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case inHunk && strings.HasPrefix(line, "+"):
+			added = append(added, line[1:])
+		}
+	}
+	flush()
+	return queue, nil
+}
+
+// sourcePaths returns the paths of the files the chores are read from.
+func sourcePaths(result *parser.ParseResult) []string {
+	paths := make([]string, len(result.Sources))
+	for i, src := range result.Sources {
+		paths[i] = src.Path
+	}
+	return paths
+}
+
+// sourceLines reads the lines of the files the chores are read from.
+func sourceLines(result *parser.ParseResult) (map[string][]string, error) {
+	lines := make(map[string][]string)
+	for _, src := range result.Sources {
+		content, err := os.ReadFile(src.Path)
+		if err != nil {
+			return nil, err
+		}
+		lines[src.Path] = strings.Split(string(content), "\n")
+	}
+	return lines, nil
+}
+
+// personName returns the name to annotate the entries of a commit's author
+// with: as mapped by authors, else the first word of the author's name, or
+// of the email address, in lower case.
+func personName(commit entryCommit, authors map[string]string) string {
+	if person, ok := authors[commit.Author]; ok {
+		return person
+	}
+	if person, ok := authors[commit.Email]; ok {
+		return person
+	}
+	words := nameWords(commit.Author)
+	if len(words) == 0 {
+		local, _, _ := strings.Cut(commit.Email, "@")
+		words = nameWords(local)
+	}
+	if len(words) == 0 {
+		return ""
+	}
+	return words[0]
+}
+
+// nameWords splits a name into lower-case words of letters, digits, "-"
+// and "_".
+func nameWords(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	})
+}
+
+// withPerson adds an @person annotation to an entry line, before its
+// comment if it has one. It reports false if the line would not read back
+// as the same entry by that person. Entry names are read against chores, as
+// a name may itself end in "@word".
+func withPerson(line string, person string, chores []model.Chore) (string, bool) {
+	body := strings.TrimRight(line, "\r")
+	eol := line[len(body):]
+	before, err := parser.Parse(body)
+	if err != nil || len(before.Completions) != 1 {
+		return line, false
+	}
+	parser.ResolvePersons(before.Completions, chores)
+	want := before.Completions[0]
+
+	// The annotation goes before a comment ("# ..." after a space) or at
+	// the end.
+	var cuts []int
+	for i := 1; i < len(body); i++ {
+		if body[i] == '#' && (body[i-1] == ' ' || body[i-1] == '\t') {
+			cuts = append(cuts, i-1)
+		}
+	}
+	cuts = append(cuts, len(strings.TrimRight(body, " \t")))
+
+	for _, cut := range cuts {
+		head := strings.TrimRight(body[:cut], " \t")
+		candidate := head + " @" + person + body[len(head):]
+		after, err := parser.Parse(candidate)
+		if err != nil || len(after.Completions) != 1 {
+			continue
+		}
+		parser.ResolvePersons(after.Completions, chores)
+		if got := after.Completions[0]; got.Person == person && entryID(got) == entryID(want) {
+			return candidate + eol, true
+		}
+	}
+	return line, false
+}
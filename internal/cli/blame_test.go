@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kusha/chores-md/internal/model"
+)
+
+// doneAs records a completion committed by the given author.
+func doneAs(t *testing.T, file string, author string, chore string, day int) {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", author)
+	if err := DoneCmd(file, chore, time.Date(2026, 2, day, 0, 0, 0, 0, time.UTC), &bytes.Buffer{}); err != nil {
+		t.Fatalf("DoneCmd error: %v", err)
+	}
+}
+
+func TestBlameCmd(t *testing.T) {
+	a, _ := gitRepos(t, "## Trash\n> 2d\n\n## Plants\n> 3d\n")
+	file := filepath.Join(a, "chores.md")
+	t.Setenv("CHORES_GIT", "commit")
+
+	doneAs(t, file, "Alice Smith", "Trash", 1)
+	doneAs(t, file, "Bob Jones", "Plants", 2)
+	doneAs(t, file, "Bob Jones", "Trash", 3)
+
+	// Reformatting an entry does not change who added it.
+	content, _ := os.ReadFile(file)
+	content = []byte(strings.Replace(string(content), "2026-02-01 Trash", "2026-02-01 trash # late", 1))
+	if err := os.WriteFile(file, content, 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	t.Setenv("GIT_AUTHOR_NAME", "Carol")
+	if _, err := runGit(a, "commit", "--quiet", "-am", "Note"); err != nil {
+		t.Fatal(err)
+	}
+	doneAs(t, file, "Carol", "Plants", 4)
+	t.Setenv("CHORES_GIT", "off")
+	doneAs(t, file, "Carol", "Plants", 5)
+
+	var buf bytes.Buffer
+	if err := BlameCmd(file, &buf); err != nil {
+		t.Fatalf("BlameCmd error: %v", err)
+	}
+	var got []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		fields := strings.Split(line, "\t")
+		got = append(got, fields[1]+": "+fields[2])
+	}
+	want := []string{
+		"Alice Smith: 2026-02-01 trash # late",
+		"Bob Jones: 2026-02-02 Plants",
+		"Bob Jones: 2026-02-03 Trash",
+		"Carol: 2026-02-04 Plants",
+		"not committed: 2026-02-05 Plants",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("BlameCmd =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	buf.Reset()
+	opts := BackfillOptions{Authors: map[string]string{"Bob Jones": "bobby"}}
+	if err := BackfillAuthorsCmd(file, opts, &buf); err != nil {
+		t.Fatalf("BackfillAuthorsCmd error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Backfilled: 4 entries") {
+		t.Errorf("output = %q", buf.String())
+	}
+	content, _ = os.ReadFile(file)
+	wantLog := "2026-02-01 trash @alice # late\n2026-02-02 Plants @bobby\n2026-02-03 Trash @bobby\n2026-02-04 Plants @carol\n2026-02-05 Plants\n"
+	if !strings.HasSuffix(string(content), wantLog) {
+		t.Errorf("chores.md =\n%s\nwant it to end with\n%s", content, wantLog)
+	}
+}
+
+func TestWithPerson(t *testing.T) {
+	tests := []struct {
+		line, want string
+	}{
+		{"2026-02-04 Trash", "2026-02-04 Trash @bob"},
+		{"2026-02-04 08:30 Trash [steps 1,2]\r", "2026-02-04 08:30 Trash [steps 1,2] @bob\r"},
+		{"2026-02-04 Trash  # taken out late", "2026-02-04 Trash @bob  # taken out late"},
+		{"2026-02-04 Trash # see #12", "2026-02-04 Trash @bob # see #12"},
+		{"2026-02-04 Email @work", "2026-02-04 Email @work @bob"},
+	}
+	chores := []model.Chore{{Name: "Trash"}, {Name: "Email @work"}}
+	for _, tt := range tests {
+		if got, ok := withPerson(tt.line, "bob", chores); !ok || got != tt.want {
+			t.Errorf("withPerson(%q) = %q, %v; want %q", tt.line, got, ok, tt.want)
+		}
+	}
+}
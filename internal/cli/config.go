@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/config"
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// ConfigGetCmd prints the effective value of a setting, as resolved for the
+// chores at file.
+func ConfigGetCmd(path string, file string, key string, out io.Writer) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	value, _, err := config.Resolve(key, nil, cfg, frontMatter(file))
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, value)
+	return nil
+}
+
+func ConfigSetCmd(path string, key string, value string, out io.Writer) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Set(key, value); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Set: %s = %s\n", key, value)
+	return nil
+}
+
+// ConfigListCmd prints every setting with its effective value for the
+// chores at file and where that value comes from.
+func ConfigListCmd(path string, file string, out io.Writer) error {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	fm := frontMatter(file)
+	for _, key := range config.Keys() {
+		value, source, err := config.Resolve(key, nil, cfg, fm)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s\t%s\t(%s)\n", key, value, source)
+	}
+
+	return nil
+}
+
+// setting resolves a setting for a command from the environment, the user
+// config at its default path and the front matter of the chores file.
+func setting(key string, frontMatter map[string]string) (string, error) {
+	var cfg *config.Config
+	if path, err := config.DefaultPath(); err == nil {
+		if cfg, err = config.Load(path); err != nil {
+			return "", err
+		}
+	}
+	value, _, err := config.Resolve(key, nil, cfg, frontMatter)
+	return value, err
+}
+
+// frontMatter returns the front matter of the chores at file, or nil if
+// they cannot be read: settings are still listed when the file is missing.
+func frontMatter(file string) map[string]string {
+	result, err := parser.ParseFile(file)
+	if err != nil {
+		return nil
+	}
+	return result.FrontMatter
+}
+
+// ChoresFile returns the chores file or directory to use: the one given with
+// -f as flag, if any, else the file setting.
+func ChoresFile(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	return pathSetting("file")
+}
+
+// pathSetting resolves a setting holding a path, in which a leading "~/"
+// stands for the home directory.
+func pathSetting(key string) (string, error) {
+	path, err := setting(key, nil)
+	if err != nil {
+		return "", err
+	}
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	return path, nil
+}
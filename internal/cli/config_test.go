@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigCmds(t *testing.T) {
+	t.Setenv("CHORES_USER", "")
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "chores", "config")
+	choresFile := filepath.Join(tmpDir, "chores.md")
+	if err := os.WriteFile(choresFile, []byte("---\ncolor: never\n---\n## Trash\n> 2d\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ConfigSetCmd(path, "user", "alice", &buf); err != nil {
+		t.Fatalf("ConfigSetCmd error: %v", err)
+	}
+
+	buf.Reset()
+	if err := ConfigGetCmd(path, choresFile, "user", &buf); err != nil {
+		t.Fatalf("ConfigGetCmd error: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "alice" {
+		t.Errorf("get user = %q, want %q", buf.String(), "alice")
+	}
+
+	buf.Reset()
+	if err := ConfigListCmd(path, choresFile, &buf); err != nil {
+		t.Fatalf("ConfigListCmd error: %v", err)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "user\talice\t(config)") {
+		t.Errorf("list should show user from config, got:\n%s", output)
+	}
+	if !strings.Contains(output, "file\tchores.md\t(default)") {
+		t.Errorf("list should show default file, got:\n%s", output)
+	}
+	if !strings.Contains(output, "color\tnever\t(front matter)") {
+		t.Errorf("list should show color from front matter, got:\n%s", output)
+	}
+
+	// A missing chores file still lists the settings.
+	buf.Reset()
+	if err := ConfigListCmd(path, filepath.Join(tmpDir, "missing.md"), &buf); err != nil {
+		t.Fatalf("ConfigListCmd error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "color\tauto\t(default)") {
+		t.Errorf("list without a chores file should show the default color, got:\n%s", buf.String())
+	}
+
+	if err := ConfigSetCmd(path, "unknown", "x", &buf); err == nil {
+		t.Error("expected error for unknown key")
+	}
+}
+
+func TestChoresFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	t.Setenv("CHORES_FILE", "")
+	if got, _ := ChoresFile(""); got != "chores.md" {
+		t.Errorf("ChoresFile() = %q, want the default chores.md", got)
+	}
+	t.Setenv("CHORES_FILE", "~/household/chores.md")
+	if got, _ := ChoresFile(""); got != filepath.Join(home, "household", "chores.md") {
+		t.Errorf("ChoresFile() = %q, want it under the home directory", got)
+	}
+	if got, _ := ChoresFile("other.md"); got != "other.md" {
+		t.Errorf("ChoresFile(flag) = %q, want the flag", got)
+	}
+}
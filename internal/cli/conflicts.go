@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// ResolveConflictsCmd merges the conflict copies a file sync tool left next
+// to the files the chores are read from (see parser.IsConflictCopy) into
+// those files, as MergeDriverCmd merges git branches, and removes them. The
+// lines a file and its copy have in common stand in for the version both
+// were changed from, so entries in either are kept, as are chores and lines
+// added to either. A line the two have in different versions, such as a
+// changed frequency, is a conflict; if there is any, nothing is written.
+func ResolveConflictsCmd(file string, out io.Writer) error {
+	result, unlock, err := lockAndParse(file, out)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	type resolution struct {
+		path    string
+		content string
+		copies  []string
+	}
+	var resolved []resolution
+	for _, src := range result.Sources {
+		copies, err := parser.ConflictCopies(src.Path)
+		if err != nil {
+			return err
+		}
+		if len(copies) == 0 {
+			continue
+		}
+		content, err := readSource(result, src.Path)
+		if err != nil {
+			return err
+		}
+
+		merged := string(content)
+		for _, path := range copies {
+			other, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			var conflicts int
+			merged, conflicts = mergeChores(commonLines(merged, string(other)), merged, string(other))
+			if conflicts > 0 {
+				return fmt.Errorf("%s and %s change the same chore definitions differently (%d conflicts); make them agree and run again", src.Path, path, conflicts)
+			}
+		}
+		resolved = append(resolved, resolution{src.Path, merged, copies})
+	}
+	if len(resolved) == 0 {
+		fmt.Fprintf(out, "No conflicting copies of %s\n", file)
+		return nil
+	}
+
+	// Every file is merged before any is written, so a conflict in one
+	// leaves them all as they were.
+	count := 0
+	for _, r := range resolved {
+		if err := writeAtomic(r.path, []byte(r.content)); err != nil {
+			return err
+		}
+		for _, path := range r.copies {
+			if err := backup(path); err != nil {
+				return fmt.Errorf("backing up %s: %w", path, err)
+			}
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "Merged: %s into %s\n", path, r.path)
+			count++
+		}
+	}
+	commitUpdate(file, result, fmt.Sprintf("resolve-conflicts: %d copies", count), out)
+	return nil
+}
+
+// conflictWarnings returns a warning for each conflict copy of the files
+// the chores are read from.
+func conflictWarnings(result *parser.ParseResult) []string {
+	var warnings []string
+	for _, src := range result.Sources {
+		copies, _ := parser.ConflictCopies(src.Path)
+		for _, path := range copies {
+			warnings = append(warnings, fmt.Sprintf("%s is a conflicting copy of %s; its entries are not read until merged with chores resolve-conflicts", path, src.Path))
+		}
+	}
+	return warnings
+}
+
+// warnConflicts prints the conflictWarnings of result.
+func warnConflicts(result *parser.ParseResult, out io.Writer) {
+	for _, warning := range conflictWarnings(result) {
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
+}
+
+// commonLines returns the lines two texts have in common, in order.
+func commonLines(a, b string) string {
+	a, b = strings.ReplaceAll(a, "\r\n", "\n"), strings.ReplaceAll(b, "\r\n", "\n")
+	var lines []string
+	for _, op := range diffLines(splitLines(a), splitLines(b)) {
+		if op.kind == ' ' {
+			lines = append(lines, op.text)
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
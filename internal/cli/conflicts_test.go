@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestResolveConflictsCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	copyFile := filepath.Join(tmpDir, "chores.sync-conflict-20260203-101530-ABCDEFG.md")
+	t.Setenv("CHORES_BACKUP_DIR", filepath.Join(tmpDir, "backups"))
+
+	base := "## Trash\n> 2d\n\n## Plants\n> 3d\n\n# Completion Log\n\n2026-02-01 Trash\n"
+	if err := os.WriteFile(testFile, []byte(base+"2026-02-03 Trash @alice\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	theirs := strings.Replace(base, "# Completion Log", "## Mop\n> 1w\n\n# Completion Log", 1) + "2026-02-02 Plants @bob\n"
+	if err := os.WriteFile(copyFile, []byte(theirs), 0644); err != nil {
+		t.Fatalf("failed to write conflict copy: %v", err)
+	}
+
+	// Every command warns about the copy.
+	var buf bytes.Buffer
+	if err := ShowCmd(testFile, time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC), &buf); err != nil {
+		t.Fatalf("ShowCmd error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Warning: "+copyFile+" is a conflicting copy of "+testFile) {
+		t.Errorf("expected a warning about the conflict copy, got:\n%s", buf.String())
+	}
+	if err := LintCmd(testFile, &bytes.Buffer{}); err == nil {
+		t.Error("expected lint to report the conflict copy")
+	}
+
+	buf.Reset()
+	if err := ResolveConflictsCmd(testFile, &buf); err != nil {
+		t.Fatalf("ResolveConflictsCmd error: %v", err)
+	}
+	if buf.String() != "Merged: "+copyFile+" into "+testFile+"\n" {
+		t.Errorf("output = %q", buf.String())
+	}
+	content, _ := os.ReadFile(testFile)
+	want := "## Trash\n> 2d\n\n## Plants\n> 3d\n\n## Mop\n> 1w\n\n# Completion Log\n\n2026-02-01 Trash\n2026-02-02 Plants @bob\n2026-02-03 Trash @alice\n"
+	if string(content) != want {
+		t.Errorf("chores.md =\n%s\nwant\n%s", content, want)
+	}
+	if _, err := os.Stat(copyFile); !os.IsNotExist(err) {
+		t.Errorf("the conflict copy should be removed, got: %v", err)
+	}
+
+	buf.Reset()
+	if err := ResolveConflictsCmd(testFile, &buf); err != nil {
+		t.Fatalf("ResolveConflictsCmd error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "No conflicting copies") {
+		t.Errorf("output = %q", buf.String())
+	}
+}
+
+func TestResolveConflictsCmd_conflict(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	copyFile := filepath.Join(tmpDir, "chores (Bob's conflicted copy 2026-02-03).md")
+
+	original := "## Trash\n> 3d\n\n2026-02-01 Trash\n"
+	if err := os.WriteFile(testFile, []byte(original), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := os.WriteFile(copyFile, []byte("## Trash\n> 1w\n\n2026-02-02 Trash\n"), 0644); err != nil {
+		t.Fatalf("failed to write conflict copy: %v", err)
+	}
+
+	if err := ResolveConflictsCmd(testFile, &bytes.Buffer{}); err == nil {
+		t.Fatal("expected an error for conflicting definitions")
+	}
+	content, _ := os.ReadFile(testFile)
+	if string(content) != original {
+		t.Errorf("nothing should be written on a conflict, got:\n%s", content)
+	}
+	if _, err := os.Stat(copyFile); err != nil {
+		t.Errorf("the conflict copy should be kept: %v", err)
+	}
+}
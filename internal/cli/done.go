@@ -3,57 +3,287 @@ package cli
 import (
 	"fmt"
 	"io"
-	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/kusha/chores-md/internal/model"
 	"github.com/kusha/chores-md/internal/parser"
+	"github.com/kusha/chores-md/internal/schedule"
 )
 
+// DoneOptions controls how a completion entry is recorded.
+type DoneOptions struct {
+	Date     time.Time // Completion date
+	WithTime bool      // Record the time of day from Date (always done for sub-daily chores)
+	By       string    // Person to attribute the completion to (defaults to the user setting)
+	Force    bool      // Record even if done the same day or before the minimum interval
+	Steps    []int     // Checklist steps done, for a partial completion (optional)
+}
+
+// findChore looks up a chore by name, case-insensitively.
+func findChore(chores []model.Chore, name string) (model.Chore, bool) {
+	nameLower := strings.ToLower(strings.TrimSpace(name))
+	for _, chore := range chores {
+		if strings.ToLower(chore.Name) == nameLower {
+			return chore, true
+		}
+	}
+	return model.Chore{}, false
+}
+
 func DoneCmd(file string, choreName string, date time.Time, out io.Writer) error {
-	result, err := parser.ParseFile(file)
+	return DoneWithOptions(file, choreName, DoneOptions{Date: date}, out)
+}
+
+func DoneWithOptions(file string, choreName string, opts DoneOptions, out io.Writer) error {
+	result, unlock, err := parseForUpdate(file, out)
 	if err != nil {
 		return err
 	}
-
-	var found bool
-	var matchedName string
-	choreNameLower := strings.ToLower(strings.TrimSpace(choreName))
-
-	for _, chore := range result.Chores {
-		if strings.ToLower(chore.Name) == choreNameLower {
-			found = true
-			matchedName = chore.Name
-			break
+	defer unlock()
+	if opts.By == "" {
+		if opts.By, err = setting("user", result.FrontMatter); err != nil {
+			return err
 		}
 	}
 
+	chore, found := findChore(result.Chores, choreName)
 	if !found {
 		return fmt.Errorf("chore not found: %q", choreName)
 	}
-
-	content, err := os.ReadFile(file)
+	steps, err := checkSteps(chore, opts.Steps)
 	if err != nil {
 		return err
 	}
+	opts.Steps = steps
+
+	if err := checkCooldown(result.Completions, chore, opts, out); err != nil {
+		return err
+	}
+
+	entry, dateStr := completionEntry(chore, opts)
+	if err := appendLog(result, opts.By, entry); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Done: %q (%s)", chore.Name, dateStr)
+	if opts.By != "" {
+		msg += " by " + opts.By
+	}
+	if len(opts.Steps) > 0 {
+		msg += fmt.Sprintf(", steps %s of %d", formatSteps(opts.Steps), len(chore.Steps))
+	}
+	fmt.Fprintln(out, msg)
 
-	dateStr := date.Format("2006-01-02")
-	entry := fmt.Sprintf("%s %s\n", dateStr, matchedName)
+	commit := fmt.Sprintf("done: %s (%s)", chore.Name, dateStr)
+	if opts.By != "" {
+		commit += " by " + opts.By
+	}
+	if len(opts.Steps) > 0 {
+		commit += fmt.Sprintf(", steps %s of %d", formatSteps(opts.Steps), len(chore.Steps))
+	}
+	commitUpdate(file, result, commit, out)
+	return nil
+}
+
+// checkSteps validates the step numbers of a partial completion against the
+// chore's checklist and returns them sorted without duplicates.
+func checkSteps(chore model.Chore, steps []int) ([]int, error) {
+	if len(steps) == 0 {
+		return nil, nil
+	}
+	if len(chore.Steps) == 0 {
+		return nil, fmt.Errorf("%q has no checklist steps", chore.Name)
+	}
+
+	seen := make(map[int]bool)
+	var result []int
+	for _, step := range steps {
+		if step < 1 || step > len(chore.Steps) {
+			return nil, fmt.Errorf("%q has no step %d (steps are 1-%d)", chore.Name, step, len(chore.Steps))
+		}
+		if !seen[step] {
+			seen[step] = true
+			result = append(result, step)
+		}
+	}
+	sort.Ints(result)
+	return result, nil
+}
 
-	if len(content) > 0 && content[len(content)-1] != '\n' {
-		entry = "\n" + entry
+// formatSteps formats step numbers as in a "[steps 1,3]" annotation.
+func formatSteps(steps []int) string {
+	strs := make([]string, len(steps))
+	for i, step := range steps {
+		strs[i] = strconv.Itoa(step)
 	}
+	return strings.Join(strs, ",")
+}
 
-	f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0644)
+// DoneRoutineCmd records a completion of every chore in a routine. All
+// entries are appended in a single write, and nothing is written if any
+// member is unknown or fails the cooldown check.
+func DoneRoutineCmd(file string, routineName string, opts DoneOptions, out io.Writer) error {
+	result, unlock, err := parseForUpdate(file, out)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
+	defer unlock()
+	if opts.By == "" {
+		if opts.By, err = setting("user", result.FrontMatter); err != nil {
+			return err
+		}
+	}
+
+	routine, found := findRoutine(result.Routines, routineName)
+	if !found {
+		return fmt.Errorf("routine not found: %q", routineName)
+	}
+	if len(routine.Members) == 0 {
+		return fmt.Errorf("routine %q has no chores", routine.Name)
+	}
+	if len(opts.Steps) > 0 {
+		return fmt.Errorf("steps cannot be recorded for a routine")
+	}
 
-	if _, err := f.WriteString(entry); err != nil {
+	var chores []model.Chore
+	for _, member := range routine.Members {
+		chore, found := findChore(result.Chores, member)
+		if !found {
+			return fmt.Errorf("routine %q: chore not found: %q", routine.Name, member)
+		}
+		if err := checkCooldown(result.Completions, chore, opts, out); err != nil {
+			return err
+		}
+		chores = append(chores, chore)
+	}
+
+	var entries []string
+	for _, chore := range chores {
+		entry, _ := completionEntry(chore, opts)
+		entries = append(entries, entry)
+	}
+	if err := appendLog(result, opts.By, strings.Join(entries, "\n")); err != nil {
 		return err
 	}
 
-	fmt.Fprintf(out, "Done: %q (%s)\n", matchedName, dateStr)
+	dateStr := opts.Date.Format("2006-01-02")
+	commit := fmt.Sprintf("done: routine %s, %d chores (%s)", routine.Name, len(chores), dateStr)
+	if opts.By != "" {
+		fmt.Fprintf(out, "Done: routine %q, %d chores (%s) by %s\n", routine.Name, len(chores), dateStr, opts.By)
+		commit += " by " + opts.By
+	} else {
+		fmt.Fprintf(out, "Done: routine %q, %d chores (%s)\n", routine.Name, len(chores), dateStr)
+	}
+	commitUpdate(file, result, commit, out)
+	return nil
+}
+
+// findRoutine looks up a routine by name, case-insensitively.
+func findRoutine(routines []model.Routine, name string) (model.Routine, bool) {
+	nameLower := strings.ToLower(strings.TrimSpace(name))
+	for _, routine := range routines {
+		if strings.ToLower(routine.Name) == nameLower {
+			return routine, true
+		}
+	}
+	return model.Routine{}, false
+}
+
+// checkCooldown compares a new completion of chore with its latest previous
+// completion on or before opts.Date. A violation is an error unless
+// opts.Force is set, in which case it is printed as a warning. Partial
+// completions are neither checked nor checked against.
+func checkCooldown(completions []model.Completion, chore model.Chore, opts DoneOptions, out io.Writer) error {
+	if len(opts.Steps) > 0 {
+		return nil
+	}
+
+	var prev *time.Time
+	for _, c := range completions {
+		if c.Steps == nil && strings.EqualFold(c.ChoreName, chore.Name) && !c.Date.After(opts.Date) && (prev == nil || c.Date.After(*prev)) {
+			date := c.Date
+			prev = &date
+		}
+	}
+	if prev == nil {
+		return nil
+	}
+	if warning := cooldownWarning(chore, *prev, opts.Date); warning != "" {
+		if !opts.Force {
+			return fmt.Errorf("%s (use --force to record anyway)", warning)
+		}
+		fmt.Fprintf(out, "Warning: %s\n", warning)
+	}
 	return nil
 }
+
+// completionEntry formats the log line recording chore as done, and the
+// date (with time, if recorded) it shows.
+func completionEntry(chore model.Chore, opts DoneOptions) (string, string) {
+	dateStr := opts.Date.Format("2006-01-02")
+	if opts.WithTime || chore.FrequencyMins > 0 {
+		dateStr = opts.Date.Format("2006-01-02 15:04")
+	}
+	entry := fmt.Sprintf("%s %s", dateStr, chore.Name)
+	if len(opts.Steps) > 0 {
+		entry += " [steps " + formatSteps(opts.Steps) + "]"
+	}
+	if opts.By != "" {
+		entry += " @" + opts.By
+	}
+	return entry, dateStr
+}
+
+// cooldownWarning returns a warning if completing chore on date would
+// duplicate its previous completion on the same day or come sooner than its
+// minimum interval, or "" if it would not. Sub-daily chores may be done
+// several times a day.
+func cooldownWarning(chore model.Chore, prev, date time.Time) string {
+	days := schedule.DaysBetween(prev, date)
+	if days == 0 && chore.FrequencyMins == 0 {
+		return fmt.Sprintf("%q was already done on %s", chore.Name, prev.Format("2006-01-02"))
+	}
+	if chore.MinDays > 0 && days < chore.MinDays {
+		return fmt.Sprintf("%q was done %s ago (%s), minimum interval is %s", chore.Name, pluralDays(days), prev.Format("2006-01-02"), chore.MinRaw)
+	}
+	return ""
+}
+
+// appendLog adds one or more log lines to the file chosen by logSource: at
+// its end or, in strict mode, after the last line of its completion log,
+// which is started at the end of the file if there is none yet.
+func appendLog(result *parser.ParseResult, by string, entry string) error {
+	src := logSource(result, by)
+	if !src.Strict {
+		return appendEntry(result, src.Path, entry)
+	}
+	if src.LogLine == 0 {
+		return appendEntry(result, src.Path, "\n# Completion Log\n\n"+entry)
+	}
+	return insertEntry(result, src.Path, src.LogLine, entry)
+}
+
+// logSource picks the file that new log entries go to when the chores are
+// split across files: one named after the person recording them (e.g.
+// log/bob.md for bob), else the last file read that has log entries, else
+// the main file.
+func logSource(result *parser.ParseResult, by string) parser.Source {
+	if by != "" {
+		for _, src := range result.Sources {
+			name := strings.TrimSuffix(filepath.Base(src.Path), filepath.Ext(src.Path))
+			if strings.EqualFold(name, by) {
+				return src
+			}
+		}
+	}
+	for i := len(result.Sources) - 1; i >= 0; i-- {
+		if result.Sources[i].Entries > 0 {
+			return result.Sources[i]
+		}
+	}
+	return result.Sources[0]
+}
@@ -128,4 +128,360 @@ func TestDoneCmd(t *testing.T) {
 			t.Errorf("entry should be on its own line, last line: %q", lastNonEmpty)
 		}
 	})
+	t.Run("attributed", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		testFile := filepath.Join(tmpDir, "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		opts := DoneOptions{Date: time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), By: "bob"}
+		var buf bytes.Buffer
+
+		if err := DoneWithOptions(testFile, "Kitchen Clean", opts, &buf); err != nil {
+			t.Fatalf("DoneWithOptions error: %v", err)
+		}
+
+		content, _ := os.ReadFile(testFile)
+		if !strings.Contains(string(content), "2026-02-10 Kitchen Clean @bob\n") {
+			t.Errorf("entry should be attributed, got:\n%s", string(content))
+		}
+		if !strings.Contains(buf.String(), "by bob") {
+			t.Errorf("output should mention person, got: %s", buf.String())
+		}
+	})
+	t.Run("user_setting", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		testFile := filepath.Join(tmpDir, "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		t.Setenv("CHORES_USER", "carol")
+
+		if err := DoneCmd(testFile, "Kitchen Clean", time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), &bytes.Buffer{}); err != nil {
+			t.Fatalf("DoneCmd error: %v", err)
+		}
+		content, _ := os.ReadFile(testFile)
+		if !strings.Contains(string(content), "2026-02-10 Kitchen Clean @carol\n") {
+			t.Errorf("entry should be attributed to the user setting, got:\n%s", content)
+		}
+	})
+	t.Run("chore_name_with_at", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte("## Email @work\n> 1d\n"), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		// The entry reads back as the chore, so a second one the same day is
+		// refused.
+		date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+		if err := DoneCmd(testFile, "Email @work", date, &bytes.Buffer{}); err != nil {
+			t.Fatalf("DoneCmd error: %v", err)
+		}
+		err := DoneCmd(testFile, "Email @work", date, &bytes.Buffer{})
+		if err == nil || !strings.Contains(err.Error(), "already done") {
+			t.Errorf("expected already done error, got: %v", err)
+		}
+	})
+
+	t.Run("sub_daily_records_time", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		testFile := filepath.Join(tmpDir, "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent+"\n## Feed Cat\n> 2x/d\n"), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		date := time.Date(2026, 2, 10, 7, 45, 0, 0, time.UTC)
+		var buf bytes.Buffer
+
+		if err := DoneCmd(testFile, "Feed Cat", date, &buf); err != nil {
+			t.Fatalf("DoneCmd error: %v", err)
+		}
+		if err := DoneCmd(testFile, "Kitchen Clean", date, &buf); err != nil {
+			t.Fatalf("DoneCmd error: %v", err)
+		}
+
+		content, _ := os.ReadFile(testFile)
+		if !strings.Contains(string(content), "2026-02-10 07:45 Feed Cat\n") {
+			t.Errorf("sub-daily entry should include time, got:\n%s", string(content))
+		}
+		if !strings.Contains(string(content), "2026-02-10 Kitchen Clean\n") {
+			t.Errorf("daily entry should be date only, got:\n%s", string(content))
+		}
+	})
+	t.Run("cooldown", func(t *testing.T) {
+		content := `## Water Cactus
+> 1m min 2w
+
+## Take Out Trash
+> 2d
+
+2026-02-01 Water Cactus
+2026-02-10 Take Out Trash
+`
+		tests := []struct {
+			name    string
+			chore   string
+			force   bool
+			wantErr string
+			wantOut string
+		}{
+			{"too_soon", "Water Cactus", false, "minimum interval is 2w", ""},
+			{"same_day", "Take Out Trash", false, "already done on 2026-02-10", ""},
+			{"forced", "Take Out Trash", true, "", "Warning: \"Take Out Trash\" was already done"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				testFile := filepath.Join(t.TempDir(), "chores.md")
+				if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write test file: %v", err)
+				}
+
+				opts := DoneOptions{Date: time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), Force: tt.force}
+				var buf bytes.Buffer
+				err := DoneWithOptions(testFile, tt.chore, opts, &buf)
+
+				after, _ := os.ReadFile(testFile)
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "--force") {
+						t.Errorf("error = %v, want %q and --force hint", err, tt.wantErr)
+					}
+					if string(after) != content {
+						t.Errorf("file should be unchanged, got:\n%s", string(after))
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("DoneWithOptions error: %v", err)
+				}
+				if !strings.Contains(buf.String(), tt.wantOut) {
+					t.Errorf("output = %q, want %q", buf.String(), tt.wantOut)
+				}
+				if strings.Count(string(after), "2026-02-10 "+tt.chore) != 2 {
+					t.Errorf("forced entry should be appended, got:\n%s", string(after))
+				}
+			})
+		}
+	})
+}
+
+func TestDoneRoutineCmd(t *testing.T) {
+	baseContent := `## Vacuum
+> 1w
+
+## Mop Floors
+> 1w
+
+## Water Cactus
+> 1m min 2w
+
+# Routine: Saturday
+- Vacuum
+- Mop Floors
+
+# Routine: Plants
+- Water Cactus
+- Mop Floors
+
+2026-02-01 Water Cactus`
+
+	date := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("appends_all_members", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := DoneRoutineCmd(testFile, "saturday", DoneOptions{Date: date, By: "bob"}, &buf); err != nil {
+			t.Fatalf("DoneRoutineCmd error: %v", err)
+		}
+
+		content, _ := os.ReadFile(testFile)
+		want := "2026-02-01 Water Cactus\n2026-02-07 Vacuum @bob\n2026-02-07 Mop Floors @bob\n"
+		if !strings.HasSuffix(string(content), want) {
+			t.Errorf("file should end with one entry per member, got:\n%s", content)
+		}
+		if !strings.Contains(buf.String(), `Done: routine "Saturday", 2 chores (2026-02-07) by bob`) {
+			t.Errorf("output should confirm the routine, got: %s", buf.String())
+		}
+	})
+
+	t.Run("cooldown_writes_nothing", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		err := DoneRoutineCmd(testFile, "Plants", DoneOptions{Date: date}, &buf)
+		if err == nil || !strings.Contains(err.Error(), "--force") {
+			t.Fatalf("expected cooldown error, got: %v", err)
+		}
+		content, _ := os.ReadFile(testFile)
+		if string(content) != baseContent {
+			t.Errorf("file should be unchanged, got:\n%s", content)
+		}
+	})
+
+	t.Run("unknown_routine", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		err := DoneRoutineCmd(testFile, "Sunday", DoneOptions{Date: date}, &buf)
+		if err == nil || !strings.Contains(err.Error(), "routine not found") {
+			t.Errorf("expected routine not found error, got: %v", err)
+		}
+	})
+}
+
+func TestDoneWithOptions_steps(t *testing.T) {
+	baseContent := `## Bathroom
+> 1w
+- [ ] Scrub toilet
+- [ ] Clean mirror
+- [ ] Mop floor
+
+## Kitchen
+> 1w
+
+2026-02-10 Bathroom
+`
+	date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		chore   string
+		steps   []int
+		wantErr string
+		want    string
+	}{
+		{"partial", "Bathroom", []int{3, 1, 3}, "", "2026-02-10 Bathroom [steps 1,3]\n"},
+		{"out_of_range", "Bathroom", []int{4}, "no step 4", ""},
+		{"no_checklist", "Kitchen", []int{1}, "no checklist", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "chores.md")
+			if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			var buf bytes.Buffer
+			err := DoneWithOptions(testFile, tt.chore, DoneOptions{Date: date, Steps: tt.steps}, &buf)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("DoneWithOptions error: %v", err)
+			}
+			content, _ := os.ReadFile(testFile)
+			if !strings.HasSuffix(string(content), tt.want) {
+				t.Errorf("file should end with %q, got:\n%s", tt.want, content)
+			}
+			if !strings.Contains(buf.String(), "steps 1,3 of 3") {
+				t.Errorf("output should mention the steps, got: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestDoneCmd_strict(t *testing.T) {
+	date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			"log_section",
+			"---\r\nstrict: true\r\n---\r\n## Trash\r\n> 2d\r\n\r\n# Completion Log\r\n2026-02-08 Trash\r\n\r\n# Notes\r\nCall the city about bulk pickup.\r\n",
+			"---\r\nstrict: true\r\n---\r\n## Trash\r\n> 2d\r\n\r\n# Completion Log\r\n2026-02-08 Trash\r\n2026-02-10 Trash\r\n\r\n# Notes\r\nCall the city about bulk pickup.\r\n",
+		},
+		{
+			"log_fence",
+			"---\nstrict: true\n---\n## Trash\n> 2d\n\n```chores-log\n```\n\nMore notes.\n",
+			"---\nstrict: true\n---\n## Trash\n> 2d\n\n```chores-log\n2026-02-10 Trash\n```\n\nMore notes.\n",
+		},
+		{
+			"no_log_yet",
+			"---\nstrict: true\n---\n## Trash\n> 2d\n",
+			"---\nstrict: true\n---\n## Trash\n> 2d\n\n# Completion Log\n\n2026-02-10 Trash\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "chores.md")
+			if err := os.WriteFile(testFile, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := DoneCmd(testFile, "Trash", date, &buf); err != nil {
+				t.Fatalf("DoneCmd error: %v", err)
+			}
+
+			content, _ := os.ReadFile(testFile)
+			if string(content) != tt.want {
+				t.Errorf("got:\n%q\nwant:\n%q", content, tt.want)
+			}
+		})
+	}
+}
+
+func TestDoneWithOptions_split(t *testing.T) {
+	date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		by       string
+		wantFile string
+	}{
+		{"person_log", "Bob", "log/bob.md"},
+		{"last_log_with_entries", "alice", "log/2026.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			files := map[string]string{
+				"chores.md":   "## Trash\n> 2d\n\n<!-- include: log/*.md -->\n",
+				"log/2026.md": "2026-02-08 Trash\n",
+				"log/bob.md":  "",
+				"log/zoe.md":  "",
+			}
+			if err := os.Mkdir(filepath.Join(dir, "log"), 0755); err != nil {
+				t.Fatal(err)
+			}
+			for name, content := range files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("failed to write %s: %v", name, err)
+				}
+			}
+
+			var buf bytes.Buffer
+			if err := DoneWithOptions(filepath.Join(dir, "chores.md"), "Trash", DoneOptions{Date: date, By: tt.by}, &buf); err != nil {
+				t.Fatalf("DoneWithOptions error: %v", err)
+			}
+
+			for name, content := range files {
+				got, _ := os.ReadFile(filepath.Join(dir, name))
+				want := content
+				if name == tt.wantFile {
+					want += "2026-02-10 Trash @" + tt.by + "\n"
+				}
+				if string(got) != want {
+					t.Errorf("%s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
 }
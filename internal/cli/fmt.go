@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// FmtCmd rewrites the file, and the files it includes, in canonical form
+// (see parser.Format), naming log entries after chores defined in any of
+// them. With check set it only reports whether they are formatted, returning
+// an error if not, which suits pre-commit hooks, and then leaves git alone.
+func FmtCmd(file string, check bool, out io.Writer) error {
+	var result *parser.ParseResult
+	var err error
+	unlock := func() {}
+	if check {
+		result, err = parser.ParseFile(file)
+	} else {
+		result, unlock, err = parseForUpdate(file, out)
+	}
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if check {
+		warnConflicts(result, out)
+	}
+
+	var formatted []string
+	var unformatted []string
+	for _, src := range result.Sources {
+		content, err := readSource(result, src.Path)
+		if err != nil {
+			return err
+		}
+
+		canonical, err := parser.FormatWithChores(string(content), result.Chores)
+		if err != nil {
+			return fmt.Errorf("%s: %w", src.Path, err)
+		}
+		if canonical == string(content) {
+			continue
+		}
+
+		if check {
+			unformatted = append(unformatted, src.Path)
+			continue
+		}
+		if err := writeAtomic(src.Path, []byte(canonical)); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Formatted: %s\n", src.Path)
+		if rel, err := filepath.Rel(choresDir(file), src.Path); err == nil {
+			formatted = append(formatted, rel)
+		} else {
+			formatted = append(formatted, src.Path)
+		}
+	}
+	if len(formatted) > 0 {
+		commitUpdate(file, result, "fmt: "+strings.Join(formatted, ", "), out)
+	}
+
+	if len(unformatted) > 0 {
+		return fmt.Errorf("not formatted: %s (run chores fmt)", strings.Join(unformatted, ", "))
+	}
+	return nil
+}
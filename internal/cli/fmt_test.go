@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFmtCmd(t *testing.T) {
+	content := "## Trash\n\n> 2d\n2026-02-02 trash\n2026-02-01 Trash\n"
+	want := "## Trash\n> 2d\n2026-02-01 Trash\n2026-02-02 Trash\n"
+
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	err := FmtCmd(testFile, true, &buf)
+	if err == nil || !strings.Contains(err.Error(), "not formatted") {
+		t.Errorf("check should fail on an unformatted file, got: %v", err)
+	}
+	if got, _ := os.ReadFile(testFile); string(got) != content {
+		t.Errorf("check should not modify the file, got:\n%s", got)
+	}
+
+	if err := FmtCmd(testFile, false, &buf); err != nil {
+		t.Fatalf("FmtCmd error: %v", err)
+	}
+	if got, _ := os.ReadFile(testFile); string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	if err := FmtCmd(testFile, true, &buf); err != nil {
+		t.Errorf("check should pass after formatting, got: %v", err)
+	}
+}
+
+func TestFmtCmd_includedLog(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	logFile := filepath.Join(tmpDir, "log", "2026.md")
+	if err := os.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(testFile, []byte("## Take Out Trash\n> 2d\n\n<!-- include: log/*.md -->\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := os.WriteFile(logFile, []byte("2026-02-03 take out trash\n"), 0644); err != nil {
+		t.Fatalf("failed to write log file: %v", err)
+	}
+
+	// The log names the chore as defined in the main file.
+	var buf bytes.Buffer
+	if err := FmtCmd(testFile, false, &buf); err != nil {
+		t.Fatalf("FmtCmd error: %v", err)
+	}
+	if got, _ := os.ReadFile(logFile); string(got) != "2026-02-03 Take Out Trash\n" {
+		t.Errorf("log =\n%s", got)
+	}
+	if err := FmtCmd(testFile, true, &buf); err != nil {
+		t.Errorf("check should pass after formatting, got: %v", err)
+	}
+}
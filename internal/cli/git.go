@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// Git modes, from the git setting.
+const (
+	gitOff    = "off"    // Leave git alone
+	gitCommit = "commit" // Commit each change
+	gitSync   = "sync"   // Also pull before reading and push after committing
+)
+
+// runGit runs git in dir and returns its output. On failure the error
+// carries what git printed.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return stdout.String(), nil
+}
+
+// gitPull brings in changes from the remote before the chores are read, in
+// sync mode. Failing to pull, for example when offline, is only a warning:
+// the change is made on what is there and pushed with the next sync.
+func gitPull(dir string, out io.Writer) {
+	if mode, err := setting("git", nil); err != nil || mode != gitSync {
+		return
+	}
+	if _, err := runGit(dir, "pull", "--no-edit"); err != nil {
+		fmt.Fprintf(out, "Warning: %v\n", err)
+	}
+}
+
+// commitUpdate commits the changes a command made to the chores at file
+// with the given message: to the files they are read from, and to the
+// archive if there is one.
+func commitUpdate(file string, result *parser.ParseResult, message string, out io.Writer) {
+	paths := sourcePaths(result)
+	if _, err := os.Stat(archiveDir(file)); err == nil {
+		paths = append(paths, archiveDir(file))
+	}
+	commitFiles(choresDir(file), paths, message, out)
+}
+
+// commitFiles commits the changes to paths with the given message, and in
+// sync mode pushes them. Git runs in dir, so paths are given to it relative
+// to dir. The change itself is already made, so git failing is only a
+// warning.
+func commitFiles(dir string, paths []string, message string, out io.Writer) {
+	mode, err := setting("git", nil)
+	if err != nil || mode == gitOff {
+		return
+	}
+	paths = gitPaths(dir, paths)
+
+	if _, err := runGit(dir, append([]string{"add", "--"}, paths...)...); err != nil {
+		fmt.Fprintf(out, "Warning: %v\n", err)
+		return
+	}
+	status, err := runGit(dir, append([]string{"status", "--porcelain", "--"}, paths...)...)
+	if err != nil {
+		fmt.Fprintf(out, "Warning: %v\n", err)
+		return
+	}
+	if strings.TrimSpace(status) == "" {
+		return
+	}
+	if _, err := runGit(dir, append([]string{"commit", "--quiet", "-m", message, "--"}, paths...)...); err != nil {
+		fmt.Fprintf(out, "Warning: %v\n", err)
+		return
+	}
+
+	if mode == gitSync {
+		if _, err := runGit(dir, "push", "--quiet"); err != nil {
+			fmt.Fprintf(out, "Warning: %v (the change is committed locally)\n", err)
+		}
+	}
+}
+
+// gitPaths returns paths relative to dir, where git is run.
+func gitPaths(dir string, paths []string) []string {
+	rel := make([]string, len(paths))
+	for i, path := range paths {
+		rel[i] = path
+		if r, err := filepath.Rel(dir, path); err == nil {
+			rel[i] = r
+		}
+	}
+	return rel
+}
+
+// choresDir returns the directory holding the chores at file: file itself
+// if it is a directory.
+func choresDir(file string) string {
+	if info, err := os.Stat(file); err == nil && info.IsDir() {
+		return file
+	}
+	return filepath.Dir(file)
+}
@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// gitRepos sets up a bare repository holding chores.md and returns two
+// clones of it, as on two machines sharing the chores.
+func gitRepos(t *testing.T, content string) (string, string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	tmpDir := t.TempDir()
+	origin, a, b := filepath.Join(tmpDir, "origin.git"), filepath.Join(tmpDir, "a"), filepath.Join(tmpDir, "b")
+	git := func(dir string, args ...string) {
+		t.Helper()
+		if _, err := runGit(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git(tmpDir, "init", "--quiet", "--bare", "--initial-branch=main", origin)
+	git(tmpDir, "clone", "--quiet", origin, a)
+	if err := os.WriteFile(filepath.Join(a, "chores.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	git(a, "add", "chores.md")
+	git(a, "commit", "--quiet", "-m", "Add chores")
+	git(a, "push", "--quiet", "origin", "HEAD:main")
+	git(tmpDir, "clone", "--quiet", origin, b)
+	return a, b
+}
+
+func TestGitSync(t *testing.T) {
+	a, b := gitRepos(t, "## Take Out Trash\n> 2d\n\n## Water Plants\n> 3d\n")
+	t.Setenv("CHORES_GIT", "sync")
+
+	var buf bytes.Buffer
+	opts := DoneOptions{Date: time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC), By: "bob"}
+	if err := DoneWithOptions(filepath.Join(a, "chores.md"), "Take Out Trash", opts, &buf); err != nil {
+		t.Fatalf("DoneWithOptions error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Warning") {
+		t.Errorf("unexpected warning:\n%s", buf.String())
+	}
+
+	// The second machine pulls the first one's entry before adding its own.
+	buf.Reset()
+	if err := DoneCmd(filepath.Join(b, "chores.md"), "Water Plants", time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC), &buf); err != nil {
+		t.Fatalf("DoneCmd error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Warning") {
+		t.Errorf("unexpected warning:\n%s", buf.String())
+	}
+	content, _ := os.ReadFile(filepath.Join(b, "chores.md"))
+	if !strings.Contains(string(content), "2026-02-04 Take Out Trash @bob\n2026-02-05 Water Plants\n") {
+		t.Errorf("chores.md =\n%s", content)
+	}
+
+	if _, err := runGit(a, "pull", "--quiet"); err != nil {
+		t.Fatal(err)
+	}
+	log, err := runGit(a, "log", "--format=%s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "done: Water Plants (2026-02-05)\ndone: Take Out Trash (2026-02-04) by bob\nAdd chores\n"
+	if log != want {
+		t.Errorf("git log =\n%s\nwant\n%s", log, want)
+	}
+}
+
+func TestGitCommit_offline(t *testing.T) {
+	a, _ := gitRepos(t, "## Trash\n> 2d\n")
+	t.Setenv("CHORES_GIT", "commit")
+	if _, err := runGit(a, "remote", "set-url", "origin", filepath.Join(t.TempDir(), "missing.git")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Commit mode never touches the remote.
+	var buf bytes.Buffer
+	if err := PauseCmd(filepath.Join(a, "chores.md"), "Trash", &buf); err != nil {
+		t.Fatalf("PauseCmd error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Warning") {
+		t.Errorf("unexpected warning:\n%s", buf.String())
+	}
+	if log, _ := runGit(a, "log", "-1", "--format=%s"); log != "pause: Trash\n" {
+		t.Errorf("last commit = %q", log)
+	}
+
+	// In sync mode an unreachable remote is a warning; the change is still
+	// made and committed.
+	t.Setenv("CHORES_GIT", "sync")
+	buf.Reset()
+	if err := ResumeCmd(filepath.Join(a, "chores.md"), "Trash", &buf); err != nil {
+		t.Fatalf("ResumeCmd error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Warning: git pull") || !strings.Contains(buf.String(), "committed locally") {
+		t.Errorf("expected pull and push warnings, got:\n%s", buf.String())
+	}
+	if log, _ := runGit(a, "log", "-1", "--format=%s"); log != "resume: Trash\n" {
+		t.Errorf("last commit = %q", log)
+	}
+}
+
+func TestGitCommit_relativePath(t *testing.T) {
+	a, _ := gitRepos(t, "## Trash\n> 2d\n")
+	t.Setenv("CHORES_GIT", "commit")
+	t.Chdir(filepath.Dir(a))
+
+	var buf bytes.Buffer
+	file := filepath.Join(filepath.Base(a), "chores.md")
+	if err := DoneCmd(file, "Trash", time.Date(2026, 2, 4, 0, 0, 0, 0, time.UTC), &buf); err != nil {
+		t.Fatalf("DoneCmd error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Warning") {
+		t.Errorf("unexpected warning:\n%s", buf.String())
+	}
+	if log, _ := runGit(a, "log", "-1", "--format=%s"); log != "done: Trash (2026-02-04)\n" {
+		t.Errorf("last commit = %q", log)
+	}
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/model"
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// DefaultWidth is the line width InfoCmd wraps to when none is given.
+const DefaultWidth = 80
+
+var (
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?-->`)
+	mdHeadingRegex   = regexp.MustCompile(`^#{1,6}\s+(.*?)\s*#*\s*$`)
+	mdListRegex      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(\[[ xX]\]\s+)?(.*)$`)
+	mdLinkRegex      = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	mdAutolinkRegex  = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+)
+
+// InfoCmd prints a chore's schedule and its description rendered for the
+// terminal: paragraphs and list items wrapped to width, code blocks kept as
+// written, HTML comments dropped, and links numbered and listed at the end.
+func InfoCmd(file string, choreName string, width int, out io.Writer) error {
+	result, err := parser.ParseFile(file)
+	if err != nil {
+		return err
+	}
+	warnConflicts(result, out)
+
+	chore, found := findChore(result.Chores, choreName)
+	if !found {
+		return fmt.Errorf("chore not found: %q", choreName)
+	}
+	if width <= 0 {
+		width = DefaultWidth
+	}
+
+	fmt.Fprintln(out, chore.Name)
+	durationStr := ""
+	if chore.DurationMinutes > 0 {
+		durationStr = " ~" + model.FormatDuration(chore.DurationMinutes)
+	}
+	fmt.Fprintln(out, describeSchedule(chore)+durationStr)
+
+	lines, links := renderMarkdown(chore.Description, width)
+	if len(lines) > 0 {
+		fmt.Fprintln(out)
+		for _, line := range lines {
+			fmt.Fprintln(out, line)
+		}
+	}
+	if len(links) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Links:")
+		for i, link := range links {
+			fmt.Fprintf(out, "  [%d] %s\n", i+1, link)
+		}
+	}
+	return nil
+}
+
+// renderMarkdown renders Markdown source as terminal lines of at most width
+// columns (except for code and single long words) and returns the targets of
+// its links in the order they are numbered in the text.
+func renderMarkdown(src string, width int) ([]string, []string) {
+	var lines, links []string
+	var words []string
+	var prefix string
+	fence := ""
+
+	flush := func() {
+		if len(words) > 0 {
+			lines = append(lines, wrapWords(words, prefix, width)...)
+		}
+		words = nil
+		prefix = ""
+	}
+	addWords := func(text string) {
+		text = mdAutolinkRegex.ReplaceAllString(text, "$1")
+		text = mdLinkRegex.ReplaceAllStringFunc(text, func(m string) string {
+			sub := mdLinkRegex.FindStringSubmatch(m)
+			links = append(links, sub[2])
+			return fmt.Sprintf("%s [%d]", sub[1], len(links))
+		})
+		words = append(words, strings.Fields(text)...)
+	}
+
+	for _, line := range strings.Split(htmlCommentRegex.ReplaceAllString(src, ""), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			} else {
+				lines = append(lines, "    "+line)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			flush()
+			fence = trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, trimmed[:1]))]
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+			if len(lines) > 0 && lines[len(lines)-1] != "" {
+				lines = append(lines, "")
+			}
+			continue
+		}
+
+		if matches := mdHeadingRegex.FindStringSubmatch(trimmed); matches != nil {
+			flush()
+			addWords(matches[1])
+			flush()
+			continue
+		}
+
+		if matches := mdListRegex.FindStringSubmatch(line); matches != nil {
+			flush()
+			prefix = matches[1] + matches[2] + " "
+			if matches[3] != "" {
+				prefix += strings.TrimSpace(matches[3]) + " "
+			}
+			addWords(matches[4])
+			continue
+		}
+
+		addWords(strings.TrimPrefix(trimmed, "> "))
+	}
+	flush()
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, links
+}
+
+// wrapWords fills words into lines of at most width columns. The first line
+// starts with prefix and the others are indented to line up with it.
+func wrapWords(words []string, prefix string, width int) []string {
+	indent := strings.Repeat(" ", len(prefix))
+	var lines []string
+	current := prefix
+	empty := true
+	for _, word := range words {
+		if !empty && len(current)+1+len(word) > width {
+			lines = append(lines, current)
+			current = indent
+			empty = true
+		}
+		if !empty {
+			current += " "
+		}
+		current += word
+		empty = false
+	}
+	return append(lines, current)
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInfoCmd(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+
+	content := "## Furnace Filter\n" +
+		"> 3m 15m\n" +
+		"\n" +
+		"Buy the pleated kind, see the [manual](https://example.com/manual) or\n" +
+		"<https://example.com/filters>.\n" +
+		"<!-- size is 16x25x1 -->\n" +
+		"\n" +
+		"### Steps\n" +
+		"- [ ] Turn off the furnace at the switch next to the stairs\n" +
+		"- [ ] Swap the filter\n" +
+		"\n" +
+		"```\n" +
+		"  airflow -> arrow toward furnace\n" +
+		"```\n"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := InfoCmd(testFile, "furnace filter", 40, &buf); err != nil {
+		t.Fatalf("InfoCmd error: %v", err)
+	}
+
+	want := `Furnace Filter
+every 3m ~15m
+
+Buy the pleated kind, see the manual [1]
+or https://example.com/filters.
+
+Steps
+- [ ] Turn off the furnace at the switch
+      next to the stairs
+- [ ] Swap the filter
+
+      airflow -> arrow toward furnace
+
+Links:
+  [1] https://example.com/manual
+`
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	err := InfoCmd(testFile, "Nonexistent", 40, &buf)
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found error, got: %v", err)
+	}
+}
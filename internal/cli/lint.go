@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/model"
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// LintCmd reports parse warnings, log entries for unknown chores, and
+// completions that were recorded the same day as the previous one or sooner
+// than the chore's minimum interval (partial completions are not checked).
+// It returns an error if anything was found.
+func LintCmd(file string, out io.Writer) error {
+	result, err := parser.ParseFile(file)
+	if err != nil {
+		return err
+	}
+
+	problems := append([]string(nil), result.Warnings...)
+	problems = append(problems, conflictWarnings(result)...)
+
+	byChore := make(map[string][]model.Completion)
+	for _, c := range result.Completions {
+		if c.Steps != nil {
+			continue
+		}
+		key := strings.ToLower(c.ChoreName)
+		byChore[key] = append(byChore[key], c)
+	}
+
+	known := make(map[string]bool)
+	for _, chore := range result.Chores {
+		key := strings.ToLower(chore.Name)
+		known[key] = true
+
+		done := byChore[key]
+		sort.SliceStable(done, func(i, j int) bool { return done[i].Date.Before(done[j].Date) })
+		for i := 1; i < len(done); i++ {
+			if warning := cooldownWarning(chore, done[i-1].Date, done[i].Date); warning != "" {
+				problems = append(problems, fmt.Sprintf("%s: %s", parser.Location(done[i].Source, done[i].Line), warning))
+			}
+		}
+	}
+
+	for _, c := range result.Completions {
+		if !known[strings.ToLower(c.ChoreName)] {
+			problems = append(problems, fmt.Sprintf("%s: completion for unknown chore %q", parser.Location(c.Source, c.Line), c.ChoreName))
+		}
+	}
+	for _, u := range result.Usages {
+		if !known[strings.ToLower(u.ChoreName)] {
+			problems = append(problems, fmt.Sprintf("%s: usage for unknown chore %q", parser.Location(u.Source, u.Line), u.ChoreName))
+		}
+	}
+
+	for _, p := range problems {
+		fmt.Fprintln(out, p)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problem(s) found", len(problems))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLintCmd(t *testing.T) {
+	t.Run("problems", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		content := `## Water Cactus
+> 1m min 2w
+
+## Take Out Trash
+> 2d
+
+## Take Out Trash
+> 3d
+
+2026-01-01 Water Cactus
+2026-01-05 Water Cactus
+2026-02-10 Take Out Trash
+2026-02-10 take out trash
+2026-02-10 Wash Car
+`
+		if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		err := LintCmd(testFile, &buf)
+		if err == nil || !strings.Contains(err.Error(), "4 problem(s)") {
+			t.Errorf("error = %v, want 4 problems", err)
+		}
+
+		output := buf.String()
+		for _, want := range []string{
+			"line 7: duplicate chore",
+			"line 11: \"Water Cactus\" was done 4 days ago (2026-01-01), minimum interval is 2w",
+			"line 13: \"Take Out Trash\" was already done on 2026-02-10",
+			"line 14: completion for unknown chore \"Wash Car\"",
+		} {
+			if !strings.Contains(output, want) {
+				t.Errorf("output should contain %q, got:\n%s", want, output)
+			}
+		}
+	})
+
+	t.Run("clean", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		content := "## Take Out Trash\n> 2d\n\n2026-02-08 Take Out Trash\n2026-02-10 Take Out Trash\n"
+		if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := LintCmd(testFile, &buf); err != nil {
+			t.Errorf("LintCmd error: %v, output:\n%s", err, buf.String())
+		}
+		if buf.Len() != 0 {
+			t.Errorf("clean file should produce no output, got:\n%s", buf.String())
+		}
+	})
+}
@@ -5,44 +5,136 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kusha/chores-md/internal/model"
 	"github.com/kusha/chores-md/internal/parser"
+	"github.com/kusha/chores-md/internal/schedule"
 )
 
 func ListCmd(file string, out io.Writer) error {
+	return ListAt(file, time.Now(), out)
+}
+
+// ListAt is ListCmd as of now, which decides whether one-off and
+// limited-run chores are finished.
+func ListAt(file string, now time.Time, out io.Writer) error {
 	result, err := parser.ParseFile(file)
 	if err != nil {
 		return err
 	}
+	warnConflicts(result, out)
 
 	completionMap := make(map[string]string)
 	for _, c := range result.Completions {
 		key := strings.ToLower(c.ChoreName)
 		dateStr := c.Date.Format("2006-01-02")
+		if c.HasTime {
+			dateStr = c.Date.Format("2006-01-02 15:04")
+		}
 		if existing, ok := completionMap[key]; !ok || dateStr > existing {
 			completionMap[key] = dateStr
 		}
 	}
 
+	statusMap := make(map[string]schedule.Status)
+	for _, cs := range schedule.CalculateWithUsage(result.Chores, result.Completions, result.Usages, now) {
+		statusMap[strings.ToLower(cs.Chore.Name)] = cs.Status
+	}
+
 	chores := make([]model.Chore, len(result.Chores))
 	copy(chores, result.Chores)
 	sort.Slice(chores, func(i, j int) bool {
 		return strings.ToLower(chores[i].Name) < strings.ToLower(chores[j].Name)
 	})
 
+	var finished, archived []model.Chore
 	for _, chore := range chores {
-		key := strings.ToLower(chore.Name)
-		lastDone := "never"
-		if date, ok := completionMap[key]; ok {
-			lastDone = date
+		switch statusMap[strings.ToLower(chore.Name)] {
+		case schedule.StatusFinished:
+			finished = append(finished, chore)
+		case schedule.StatusArchived:
+			archived = append(archived, chore)
+		default:
+			printListLine(out, chore, completionMap)
 		}
-		durationStr := ""
-		if chore.DurationMinutes > 0 {
-			durationStr = " ~" + model.FormatDuration(chore.DurationMinutes)
+	}
+
+	if len(finished) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "FINISHED")
+		for _, chore := range finished {
+			printListLine(out, chore, completionMap)
+		}
+	}
+
+	if len(archived) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "ARCHIVED")
+		for _, chore := range archived {
+			printListLine(out, chore, completionMap)
 		}
-		fmt.Fprintf(out, "%s\tevery %s%s\tLast: %s\n", chore.Name, chore.FrequencyRaw, durationStr, lastDone)
 	}
 
 	return nil
 }
+
+func printListLine(out io.Writer, chore model.Chore, completionMap map[string]string) {
+	lastDone := "never"
+	if date, ok := completionMap[strings.ToLower(chore.Name)]; ok {
+		lastDone = date
+	}
+	durationStr := ""
+	if chore.DurationMinutes > 0 {
+		durationStr = " ~" + model.FormatDuration(chore.DurationMinutes)
+	}
+	fmt.Fprintf(out, "%s\t%s%s\tLast: %s\n", chore.Name, describeSchedule(chore), durationStr, lastDone)
+}
+
+// describeSchedule formats a chore's frequency and clauses for display,
+// e.g. "every 1w grace 2d in apr-oct", "3x/w" or "once by 2026-03-01".
+func describeSchedule(chore model.Chore) string {
+	s := "every " + chore.FrequencyRaw
+	if chore.Once || strings.Contains(chore.FrequencyRaw, "x/") {
+		s = chore.FrequencyRaw
+	}
+	if chore.UsageLimit > 0 {
+		s = fmt.Sprintf("every %d %s", chore.UsageLimit, chore.UsageUnit)
+		if chore.FrequencyRaw != "" {
+			s += " or " + chore.FrequencyRaw
+		}
+	}
+	if chore.AfterChore != "" {
+		after := "after " + chore.AfterChore
+		if chore.AfterWithinRaw != "" {
+			after += " within " + chore.AfterWithinRaw
+		}
+		if chore.HasSchedule() {
+			s += " " + after
+		} else {
+			s = after
+		}
+	}
+	if !chore.Deadline.IsZero() {
+		s += " by " + chore.Deadline.Format("2006-01-02")
+	}
+	if chore.MaxCount > 0 {
+		s += fmt.Sprintf(" x%d", chore.MaxCount)
+	}
+	if !chore.Until.IsZero() {
+		s += " until " + chore.Until.Format("2006-01-02")
+	}
+	if chore.GraceDays > 0 {
+		s += " grace " + chore.GraceRaw
+	}
+	if chore.MinDays > 0 {
+		s += " min " + chore.MinRaw
+	}
+	if chore.ActiveFrom != 0 {
+		s += " in " + chore.ActiveRaw
+	}
+	if chore.State == model.StatePaused {
+		s += " paused"
+	}
+	return s
+}
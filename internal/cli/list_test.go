@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListCmd(t *testing.T) {
@@ -61,3 +62,68 @@ func TestListCmd(t *testing.T) {
 		t.Errorf("Beta Task should show 'never', got: %s", lines[1])
 	}
 }
+
+func TestListAt_sections(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+
+	now := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	content := `## Replace Caulk
+> once by 2026-03-01
+
+## Medication
+> 1d x2
+
+## Take Out Trash
+> 2d
+
+## Wash Car
+> 2w archived
+
+## Water Cactus
+> 1m paused
+
+## Clean Mower Deck
+after: Take Out Trash within 2d
+
+2026-02-08 Replace Caulk
+2026-02-08 Medication
+2026-02-09 Medication
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ListAt(testFile, now, &buf); err != nil {
+		t.Fatalf("ListAt error: %v", err)
+	}
+
+	active, rest, ok := strings.Cut(buf.String(), "\nFINISHED\n")
+	if !ok {
+		t.Fatalf("missing FINISHED section, got:\n%s", buf.String())
+	}
+	finished, archived, ok := strings.Cut(rest, "\nARCHIVED\n")
+	if !ok {
+		t.Fatalf("missing ARCHIVED section, got:\n%s", buf.String())
+	}
+	if !strings.Contains(active, "Clean Mower Deck\tafter Take Out Trash within 2d\tLast: never") {
+		t.Errorf("follow-up chore should describe its trigger, got:\n%s", active)
+	}
+	if !strings.Contains(active, "Water Cactus\tevery 1m paused") {
+		t.Errorf("paused chore should be listed as paused, got:\n%s", active)
+	}
+	if !strings.Contains(archived, "Wash Car\tevery 2w\tLast: never") {
+		t.Errorf("archived chore should be in its own section, got:\n%s", archived)
+	}
+	if !strings.Contains(active, "Take Out Trash\tevery 2d") {
+		t.Errorf("active chore should be listed first, got:\n%s", active)
+	}
+	if !strings.Contains(finished, "Medication\tevery 1d x2\tLast: 2026-02-09") {
+		t.Errorf("limited run should be finished, got:\n%s", finished)
+	}
+	if !strings.Contains(finished, "Replace Caulk\tonce by 2026-03-01\tLast: 2026-02-08") {
+		t.Errorf("one-off should be finished, got:\n%s", finished)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package cli
+
+import "time"
+
+// lockDir does nothing where advisory locks are not available; writes are
+// still atomic and checked against changes made since parsing.
+func lockDir(dir string, timeout time.Duration) (func(), error) {
+	return func() {}, nil
+}
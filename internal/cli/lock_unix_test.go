@@ -0,0 +1,76 @@
+//go:build unix
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+func TestDoneCmd_concurrent(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+
+	var sb strings.Builder
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&sb, "## Chore %d\n> 1d\n\n", i)
+	}
+	if err := os.WriteFile(testFile, []byte(sb.String()), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- DoneCmd(testFile, fmt.Sprintf("Chore %d", i), date, io.Discard)
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("DoneCmd error: %v", err)
+		}
+	}
+
+	result, err := parser.ParseFile(testFile)
+	if err != nil {
+		t.Fatalf("parse error: %v", err)
+	}
+	if len(result.Completions) != 20 {
+		t.Errorf("got %d completions, want 20", len(result.Completions))
+	}
+}
+
+func TestDoneCmd_locked(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	if err := os.WriteFile(testFile, []byte("## Trash\n> 2d\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	unlock, err := lockDir(tmpDir, time.Second)
+	if err != nil {
+		t.Fatalf("lockDir error: %v", err)
+	}
+	defer unlock()
+
+	defer func(timeout time.Duration) { lockTimeout = timeout }(lockTimeout)
+	lockTimeout = 50 * time.Millisecond
+
+	err = DoneCmd(testFile, "Trash", time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC), io.Discard)
+	if err == nil || !strings.Contains(err.Error(), "locked by another chores command") {
+		t.Errorf("expected a lock error, got: %v", err)
+	}
+}
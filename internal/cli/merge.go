@@ -0,0 +1,380 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// MergeDriverCmd merges two versions of a chores file changed from a common
+// base, as a git merge driver invoked as "chores merge-driver %O %A %B": the
+// result replaces ours. Log entries added on either side are all kept, and
+// entries removed on either side stay removed. The rest of the file is merged
+// line by line; changes that overlap are left between conflict markers and
+// reported as an error, so that git stops for them to be resolved.
+func MergeDriverCmd(base, ours, theirs string, out io.Writer) error {
+	var versions [3]string
+	for i, path := range []string{base, ours, theirs} {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		versions[i] = string(content)
+	}
+
+	merged, conflicts := mergeChores(versions[0], versions[1], versions[2])
+	if err := os.WriteFile(ours, []byte(merged), 0644); err != nil {
+		return err
+	}
+	if conflicts > 0 {
+		return fmt.Errorf("%d conflicting changes to chore definitions (marked with <<<<<<<)", conflicts)
+	}
+	return nil
+}
+
+// InstallMergeDriverCmd sets up the git repository holding the chores at
+// file to merge them with MergeDriverCmd: the driver is defined in the
+// repository's config, which is not shared, and the files are assigned to
+// it in .gitattributes next to them, which is.
+func InstallMergeDriverCmd(file string, out io.Writer) error {
+	dir := choresDir(file)
+	if _, err := runGit(dir, "rev-parse", "--git-dir"); err != nil {
+		return err
+	}
+
+	var patterns []string
+	if dir == file {
+		patterns = []string{"*.md"}
+	} else {
+		result, err := parser.ParseFile(file)
+		if err != nil {
+			return err
+		}
+		for _, src := range result.Sources {
+			if rel, err := filepath.Rel(dir, src.Path); err == nil && !strings.HasPrefix(rel, "..") {
+				patterns = append(patterns, filepath.ToSlash(rel))
+			}
+		}
+		patterns = append(patterns, "archive/*.md")
+	}
+
+	if _, err := runGit(dir, "config", "merge.chores.name", "chores log merge"); err != nil {
+		return err
+	}
+	if _, err := runGit(dir, "config", "merge.chores.driver", "chores merge-driver %O %A %B"); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Installed: merge driver \"chores\" in the git config")
+
+	path := filepath.Join(dir, ".gitattributes")
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	existing := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		existing[strings.TrimSpace(line)] = true
+	}
+	var added []string
+	for _, pattern := range patterns {
+		line := pattern + " merge=chores"
+		if !existing[line] {
+			existing[line] = true
+			added = append(added, line)
+		}
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		content = append(content, '\n')
+	}
+	content = append(content, strings.Join(added, "\n")+"\n"...)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return err
+	}
+	for _, line := range added {
+		fmt.Fprintf(out, "Added: %q to %s\n", line, path)
+	}
+	return nil
+}
+
+// entryMarker stands in for a run of log entries while the rest of a file is
+// merged line by line. It cannot occur in a line of text.
+const entryMarker = "\x00entries"
+
+// mergeVersion is one version of a chores file, split for merging into its
+// log entries and the rest.
+type mergeVersion struct {
+	skeleton []string         // Lines, with each run of entries replaced by entryMarker
+	runs     map[int][]string // The entries of each run, by the index of its marker
+	entries  map[string]bool  // Every entry, by entryKey
+}
+
+// splitVersion splits a version of a chores file for merging. A version
+// that does not parse is merged line by line as a whole.
+func splitVersion(content string) mergeVersion {
+	isEntry := make(map[int]bool)
+	if result, err := parser.Parse(content); err == nil {
+		for _, c := range result.Completions {
+			isEntry[c.Line-1] = true
+		}
+		for _, u := range result.Usages {
+			isEntry[u.Line-1] = true
+		}
+	}
+
+	v := mergeVersion{runs: make(map[int][]string), entries: make(map[string]bool)}
+	for i, line := range strings.Split(content, "\n") {
+		if !isEntry[i] {
+			v.skeleton = append(v.skeleton, line)
+			continue
+		}
+		if !isEntry[i-1] {
+			v.skeleton = append(v.skeleton, entryMarker)
+		}
+		at := len(v.skeleton) - 1
+		v.runs[at] = append(v.runs[at], line)
+		v.entries[entryKey(line)] = true
+	}
+	return v
+}
+
+// entryKey identifies a log entry: entries differing only in surrounding
+// space are the same.
+func entryKey(line string) string {
+	return strings.TrimSpace(line)
+}
+
+// entryTime returns the date and time an entry starts with, in an order
+// that sorts chronologically.
+func entryTime(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) > 1 && len(fields[1]) == 5 && fields[1][2] == ':' {
+		return fields[0] + " " + fields[1]
+	}
+	return fields[0]
+}
+
+// mergeChores merges two versions of a chores file changed from base and
+// returns the result and how many conflicts it marks.
+//
+// The lines other than log entries are merged as by diff3, with each run of
+// entries standing in as one line. The runs of ours are then filled in with
+// their entries, and the entries added by theirs are put into the run that
+// holds them in theirs, in date order. An entry in base that one side
+// removed is dropped, and an entry both sides added is kept once.
+func mergeChores(base, ours, theirs string) (string, int) {
+	crlf := strings.Contains(ours, "\r\n")
+	vb := splitVersion(strings.ReplaceAll(base, "\r\n", "\n"))
+	vo := splitVersion(strings.ReplaceAll(ours, "\r\n", "\n"))
+	vt := splitVersion(strings.ReplaceAll(theirs, "\r\n", "\n"))
+
+	merged, conflicts := merge3(vb.skeleton, vo.skeleton, vt.skeleton)
+
+	runs := make(map[int][]string)
+	last := -1
+	oursPlaced, theirsPlaced := make(map[int]bool), make(map[int]bool)
+	for i, line := range merged {
+		if line.text != entryMarker {
+			continue
+		}
+		last = i
+		if line.ours >= 0 {
+			oursPlaced[line.ours] = true
+			for _, entry := range vo.runs[line.ours] {
+				if key := entryKey(entry); !vb.entries[key] || vt.entries[key] {
+					runs[i] = append(runs[i], entry)
+				}
+			}
+		}
+	}
+
+	// Entries whose run did not survive the merge go to the last run, or if
+	// there is none, to the end of the file.
+	var homeless []string
+	for _, at := range sortedKeys(vo.runs) {
+		if oursPlaced[at] {
+			continue
+		}
+		for _, entry := range vo.runs[at] {
+			if key := entryKey(entry); !vb.entries[key] || vt.entries[key] {
+				homeless = append(homeless, entry)
+			}
+		}
+	}
+
+	added := make(map[string]bool)
+	isNew := func(entry string) bool {
+		key := entryKey(entry)
+		if vo.entries[key] || vb.entries[key] || added[key] {
+			return false
+		}
+		added[key] = true
+		return true
+	}
+	for i, line := range merged {
+		if line.text != entryMarker || line.theirs < 0 {
+			continue
+		}
+		theirsPlaced[line.theirs] = true
+		for _, entry := range vt.runs[line.theirs] {
+			if isNew(entry) {
+				runs[i] = insertByTime(runs[i], entry)
+			}
+		}
+	}
+	for _, at := range sortedKeys(vt.runs) {
+		if theirsPlaced[at] {
+			continue
+		}
+		for _, entry := range vt.runs[at] {
+			if isNew(entry) {
+				homeless = append(homeless, entry)
+			}
+		}
+	}
+
+	if len(homeless) > 0 {
+		if last < 0 {
+			last = len(merged)
+			if last > 0 && merged[last-1].text == "" {
+				last--
+			}
+			merged = slices.Insert(merged, last, mergeLine{entryMarker, -1, -1})
+		}
+		for _, entry := range homeless {
+			runs[last] = insertByTime(runs[last], entry)
+		}
+	}
+
+	var lines []string
+	for i, line := range merged {
+		if line.text == entryMarker {
+			lines = append(lines, runs[i]...)
+		} else {
+			lines = append(lines, line.text)
+		}
+	}
+	eol := "\n"
+	if crlf {
+		eol = "\r\n"
+	}
+	return strings.Join(lines, eol), conflicts
+}
+
+// insertByTime inserts entry into a run of entries after those dated no
+// later than it.
+func insertByTime(run []string, entry string) []string {
+	t := entryTime(entry)
+	i := len(run)
+	for j, e := range run {
+		if entryTime(e) > t {
+			i = j
+			break
+		}
+	}
+	return slices.Insert(run, i, entry)
+}
+
+// sortedKeys returns the keys of runs in increasing order.
+func sortedKeys(runs map[int][]string) []int {
+	keys := make([]int, 0, len(runs))
+	for k := range runs {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// mergeLine is a line of a three-way merge and the index of the line it
+// comes from in ours and in theirs, or -1.
+type mergeLine struct {
+	text         string
+	ours, theirs int
+}
+
+// merge3 merges the changes from base to ours and to theirs as diff3 does.
+// Where both changed the same lines differently, both changes are kept
+// between conflict markers. It returns the merged lines and the number of
+// conflicts.
+func merge3(base, ours, theirs []string) ([]mergeLine, int) {
+	mo, mt := matchLines(base, ours), matchLines(base, theirs)
+
+	var merged []mergeLine
+	conflicts := 0
+	i, a, b := 0, 0, 0
+	for i < len(base) || a < len(ours) || b < len(theirs) {
+		if i < len(base) && mo[i] == a && mt[i] == b {
+			merged = append(merged, mergeLine{base[i], a, b})
+			i, a, b = i+1, a+1, b+1
+			continue
+		}
+
+		// A changed chunk runs to the next line of base both sides kept.
+		j := i
+		for j < len(base) && (mo[j] < 0 || mt[j] < 0) {
+			j++
+		}
+		aEnd, bEnd := len(ours), len(theirs)
+		if j < len(base) {
+			aEnd, bEnd = mo[j], mt[j]
+		}
+		o, t := ours[a:aEnd], theirs[b:bEnd]
+
+		switch {
+		case slices.Equal(o, base[i:j]):
+			for k, line := range t {
+				merged = append(merged, mergeLine{line, -1, b + k})
+			}
+		case slices.Equal(t, base[i:j]):
+			for k, line := range o {
+				merged = append(merged, mergeLine{line, a + k, -1})
+			}
+		case slices.Equal(o, t):
+			for k, line := range o {
+				merged = append(merged, mergeLine{line, a + k, b + k})
+			}
+		default:
+			conflicts++
+			merged = append(merged, mergeLine{"<<<<<<< ours", -1, -1})
+			for k, line := range o {
+				merged = append(merged, mergeLine{line, a + k, -1})
+			}
+			merged = append(merged, mergeLine{"=======", -1, -1})
+			for k, line := range t {
+				merged = append(merged, mergeLine{line, -1, b + k})
+			}
+			merged = append(merged, mergeLine{">>>>>>> theirs", -1, -1})
+		}
+		i, a, b = j, aEnd, bEnd
+	}
+	return merged, conflicts
+}
+
+// matchLines returns, for each line of a, the index of the line of b it is
+// kept as in a shortest edit from a to b, or -1 if it is removed.
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	i, j := 0, 0
+	for _, op := range diffLines(a, b) {
+		switch op.kind {
+		case ' ':
+			match[i] = j
+			i++
+			j++
+		case '-':
+			match[i] = -1
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMergeChores(t *testing.T) {
+	base := "## Trash\n> 2d\n\n## Plants\n> 3d\n\n# Completion Log\n\n2026-02-01 Trash\n2026-02-02 Plants\n"
+
+	tests := []struct {
+		name          string
+		base          string // Defaults to base
+		ours, theirs  string
+		want          string
+		wantConflicts int
+	}{
+		{
+			name:   "both_append",
+			ours:   base + "2026-02-04 Trash @alice\n",
+			theirs: base + "2026-02-03 Plants @bob\n2026-02-05 Trash @bob\n",
+			want:   base + "2026-02-03 Plants @bob\n2026-02-04 Trash @alice\n2026-02-05 Trash @bob\n",
+		},
+		{
+			name:   "same_entry",
+			ours:   base + "2026-02-04 Trash\n",
+			theirs: base + "2026-02-04 Trash\n",
+			want:   base + "2026-02-04 Trash\n",
+		},
+		{
+			name:   "removed_entry",
+			ours:   strings.Replace(base, "2026-02-01 Trash\n", "", 1),
+			theirs: base + "2026-02-04 Trash\n",
+			want:   strings.Replace(base, "2026-02-01 Trash\n", "", 1) + "2026-02-04 Trash\n",
+		},
+		{
+			name:   "definitions",
+			ours:   strings.Replace(base, "> 2d", "> 3d", 1) + "2026-02-04 Trash\n",
+			theirs: strings.Replace(base, "## Plants\n> 3d\n", "## Plants\n> 3d\n\nWater the ferns too.\n", 1) + "2026-02-05 Plants\n",
+			want: "## Trash\n> 3d\n\n## Plants\n> 3d\n\nWater the ferns too.\n\n# Completion Log\n\n" +
+				"2026-02-01 Trash\n2026-02-02 Plants\n2026-02-04 Trash\n2026-02-05 Plants\n",
+		},
+		{
+			name:   "conflict",
+			ours:   strings.Replace(base, "> 2d", "> 3d", 1) + "2026-02-04 Trash\n",
+			theirs: strings.Replace(base, "> 2d", "> 1w", 1) + "2026-02-05 Trash\n",
+			want: "## Trash\n<<<<<<< ours\n> 3d\n=======\n> 1w\n>>>>>>> theirs\n\n## Plants\n> 3d\n\n# Completion Log\n\n" +
+				"2026-02-01 Trash\n2026-02-02 Plants\n2026-02-04 Trash\n2026-02-05 Trash\n",
+			wantConflicts: 1,
+		},
+		{
+			name:   "first_entries",
+			base:   "## Trash\n> 2d\n",
+			ours:   "## Trash\n> 2d\n\n2026-02-04 Trash\n",
+			theirs: "## Trash\n> 2d\n\n2026-02-03 Trash\n",
+			want:   "## Trash\n> 2d\n\n2026-02-03 Trash\n2026-02-04 Trash\n",
+		},
+		{
+			name:   "crlf",
+			ours:   strings.ReplaceAll(base+"2026-02-04 Trash\n", "\n", "\r\n"),
+			theirs: base + "2026-02-05 Trash\n",
+			want:   strings.ReplaceAll(base+"2026-02-04 Trash\n2026-02-05 Trash\n", "\n", "\r\n"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := base
+			if tt.base != "" {
+				b = tt.base
+			}
+			got, conflicts := mergeChores(b, tt.ours, tt.theirs)
+			if got != tt.want {
+				t.Errorf("mergeChores =\n%s\nwant\n%s", got, tt.want)
+			}
+			if conflicts != tt.wantConflicts {
+				t.Errorf("conflicts = %d, want %d", conflicts, tt.wantConflicts)
+			}
+		})
+	}
+}
+
+func TestMergeDriverCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		return path
+	}
+	base := write("base", "## Trash\n> 2d\n")
+	ours := write("ours", "## Trash\n> 2d\n\n2026-02-04 Trash\n")
+	theirs := write("theirs", "## Trash\n> 2d\n\n2026-02-04 Trash\n2026-02-05 Trash\n")
+
+	if err := MergeDriverCmd(base, ours, theirs, &bytes.Buffer{}); err != nil {
+		t.Fatalf("MergeDriverCmd error: %v", err)
+	}
+	content, _ := os.ReadFile(ours)
+	if string(content) != "## Trash\n> 2d\n\n2026-02-04 Trash\n2026-02-05 Trash\n" {
+		t.Errorf("merged =\n%s", content)
+	}
+
+	write("ours", "## Trash\n> 3d\n")
+	write("theirs", "## Trash\n> 1w\n")
+	if err := MergeDriverCmd(base, ours, theirs, &bytes.Buffer{}); err == nil {
+		t.Error("expected an error for conflicting changes")
+	}
+}
+
+func TestInstallMergeDriverCmd(t *testing.T) {
+	a, _ := gitRepos(t, "## Trash\n> 2d\n")
+	file := filepath.Join(a, "chores.md")
+
+	for range 2 {
+		if err := InstallMergeDriverCmd(file, &bytes.Buffer{}); err != nil {
+			t.Fatalf("InstallMergeDriverCmd error: %v", err)
+		}
+	}
+	content, _ := os.ReadFile(filepath.Join(a, ".gitattributes"))
+	if string(content) != "chores.md merge=chores\narchive/*.md merge=chores\n" {
+		t.Errorf(".gitattributes =\n%s", content)
+	}
+	if driver, _ := runGit(a, "config", "merge.chores.driver"); driver != "chores merge-driver %O %A %B\n" {
+		t.Errorf("merge.chores.driver = %q", driver)
+	}
+	if attr, _ := runGit(a, "check-attr", "merge", "chores.md"); attr != "chores.md: merge: chores\n" {
+		t.Errorf("check-attr = %q", attr)
+	}
+}
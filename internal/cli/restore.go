@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// RestoreListCmd lists the snapshots of the chores file, the files it
+// includes and its archive, newest first.
+func RestoreListCmd(file string, out io.Writer) error {
+	_, root, err := backupSettings()
+	if err != nil {
+		return err
+	}
+
+	var snapshots []snapshot
+	for _, f := range restoreFiles(file) {
+		s, err := listSnapshots(root, f)
+		if err != nil {
+			return err
+		}
+		snapshots = append(snapshots, s...)
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintf(out, "No snapshots of %s\n", file)
+		return nil
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool { return snapshots[i].Time.After(snapshots[j].Time) })
+	for _, s := range snapshots {
+		fmt.Fprintf(out, "%s\t%s\t%s\n", s.ID, s.Time.Format("2006-01-02 15:04:05"), s.File)
+	}
+	return nil
+}
+
+// RestoreCmd shows how restoring a snapshot, given by its ID or a unique
+// prefix of it, would change the file it is a backup of, and with apply set
+// restores it. The content it replaces is backed up first, so a restore can
+// itself be undone.
+func RestoreCmd(file string, id string, apply bool, out io.Writer) error {
+	_, root, err := backupSettings()
+	if err != nil {
+		return err
+	}
+	s, err := findSnapshot(root, restoreFiles(file), id)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(s.File)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	diff := unifiedDiff(string(current), string(content), s.File+" (current)", s.File+" (snapshot "+s.ID+")")
+	if diff == "" {
+		fmt.Fprintf(out, "%s already matches snapshot %s\n", s.File, s.ID)
+		return nil
+	}
+	fmt.Fprint(out, diff)
+	if !apply {
+		fmt.Fprintf(out, "\nRun again with --yes to restore %s.\n", s.File)
+		return nil
+	}
+
+	unlock, err := lockDir(choresDir(file), lockTimeout)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := writeAtomic(s.File, content); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "\nRestored: %s from %s\n", s.File, s.ID)
+	commitFiles(choresDir(file), []string{s.File}, fmt.Sprintf("restore: %s from %s", filepath.Base(s.File), s.ID), out)
+	return nil
+}
+
+// restoreFiles returns the files whose snapshots can be restored: those the
+// chores are read from and the archive. Restoring is for when things went
+// wrong, so if the chores cannot be parsed the file or directory given is
+// used as is.
+func restoreFiles(file string) []string {
+	var files []string
+	if result, err := parser.ParseFile(file); err == nil {
+		for _, src := range result.Sources {
+			files = append(files, src.Path)
+		}
+	} else if info, err := os.Stat(file); err == nil && info.IsDir() {
+		files, _ = filepath.Glob(filepath.Join(file, "*.md"))
+	} else {
+		files = []string{file}
+	}
+	archived, _ := filepath.Glob(filepath.Join(archiveDir(file), "*.md"))
+	return append(files, archived...)
+}
+
+// diffOp is one line of a line-by-line diff: ' ' kept, '-' removed or '+'
+// added.
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// maxDiffCells bounds the table used to diff the changed middle of two texts.
+// Beyond it the middle is shown as removed and added as a whole.
+const maxDiffCells = 4 << 20
+
+// unifiedDiff returns the changes from one text to another in unified diff
+// format with three lines of context, or "" if they are the same.
+func unifiedDiff(from, to string, fromName, toName string) string {
+	ops := diffLines(splitLines(from), splitLines(to))
+
+	const context = 3
+	var sb strings.Builder
+	aLine, bLine := make([]int, len(ops)+1), make([]int, len(ops)+1)
+	for i, op := range ops {
+		aLine[i+1], bLine[i+1] = aLine[i], bLine[i]
+		if op.kind != '+' {
+			aLine[i+1]++
+		}
+		if op.kind != '-' {
+			bLine[i+1]++
+		}
+	}
+
+	for i := 0; i < len(ops); {
+		for i < len(ops) && ops[i].kind == ' ' {
+			i++
+		}
+		if i == len(ops) {
+			break
+		}
+		end := i + 1
+		for j := i; j < len(ops) && j <= end+2*context; j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			}
+		}
+		start, stop := max(0, i-context), min(len(ops), end+context)
+
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(aLine[start], aLine[stop]), hunkRange(bLine[start], bLine[stop]))
+		for _, op := range ops[start:stop] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			sb.WriteByte('\n')
+		}
+		i = stop
+	}
+	return sb.String()
+}
+
+// hunkRange formats the lines from index start to stop of a hunk header.
+func hunkRange(start, stop int) string {
+	if stop == start {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, stop-start)
+}
+
+// diffLines returns a shortest edit from a to b. Lines in common at the start
+// and end are matched directly; the rest by longest common subsequence.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []diffOp
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	am, bm := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	n, m := len(am), len(bm)
+	if (n+1)*(m+1) > maxDiffCells {
+		for _, line := range am {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range bm {
+			ops = append(ops, diffOp{'+', line})
+		}
+	} else {
+		// lcs[i*(m+1)+j] is the length of the longest common subsequence
+		// of am[i:] and bm[j:].
+		lcs := make([]int32, (n+1)*(m+1))
+		for i := n - 1; i >= 0; i-- {
+			for j := m - 1; j >= 0; j-- {
+				if am[i] == bm[j] {
+					lcs[i*(m+1)+j] = lcs[(i+1)*(m+1)+j+1] + 1
+				} else {
+					lcs[i*(m+1)+j] = max(lcs[(i+1)*(m+1)+j], lcs[i*(m+1)+j+1])
+				}
+			}
+		}
+		i, j := 0, 0
+		for i < n || j < m {
+			switch {
+			case i < n && j < m && am[i] == bm[j]:
+				ops = append(ops, diffOp{' ', am[i]})
+				i++
+				j++
+			case j == m || (i < n && lcs[(i+1)*(m+1)+j] >= lcs[i*(m+1)+j+1]):
+				ops = append(ops, diffOp{'-', am[i]})
+				i++
+			default:
+				ops = append(ops, diffOp{'+', bm[j]})
+				j++
+			}
+		}
+	}
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// splitLines splits text into lines without their line breaks.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
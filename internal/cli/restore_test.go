@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRestoreCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	t.Setenv("CHORES_BACKUP_DIR", filepath.Join(tmpDir, "backups"))
+	t.Setenv("CHORES_BACKUPS", "2")
+
+	original := "## Trash\n> 2d\n\n2026-02-01 Trash\n"
+	if err := os.WriteFile(testFile, []byte(original), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	for _, day := range []int{2, 3, 4} {
+		if err := DoneCmd(testFile, "Trash", time.Date(2026, 2, day, 0, 0, 0, 0, time.UTC), &bytes.Buffer{}); err != nil {
+			t.Fatalf("DoneCmd error: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := RestoreListCmd(testFile, &buf); err != nil {
+		t.Fatalf("RestoreListCmd error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected the 2 newest snapshots to be kept, got:\n%s", buf.String())
+	}
+	oldest := strings.Split(lines[1], "\t")[0]
+
+	// Without --yes the change is only previewed.
+	buf.Reset()
+	if err := RestoreCmd(testFile, oldest, false, &buf); err != nil {
+		t.Fatalf("RestoreCmd error: %v", err)
+	}
+	want := "@@ -3,5 +3,3 @@\n \n 2026-02-01 Trash\n 2026-02-02 Trash\n-2026-02-03 Trash\n-2026-02-04 Trash\n"
+	if !strings.Contains(buf.String(), want) || !strings.Contains(buf.String(), "--yes") {
+		t.Errorf("preview =\n%s\nwant a diff containing\n%s", buf.String(), want)
+	}
+	content, _ := os.ReadFile(testFile)
+	if strings.Count(string(content), "2026-") != 4 {
+		t.Errorf("preview should not change the file, got:\n%s", content)
+	}
+
+	buf.Reset()
+	if err := RestoreCmd(testFile, oldest, true, &buf); err != nil {
+		t.Fatalf("RestoreCmd error: %v", err)
+	}
+	content, _ = os.ReadFile(testFile)
+	if string(content) != original+"2026-02-02 Trash\n" {
+		t.Errorf("restored file =\n%s", content)
+	}
+	if !strings.Contains(buf.String(), "Restored: "+testFile) {
+		t.Errorf("output = %q", buf.String())
+	}
+
+	// The restore itself was backed up and can be undone.
+	buf.Reset()
+	if err := RestoreListCmd(testFile, &buf); err != nil {
+		t.Fatalf("RestoreListCmd error: %v", err)
+	}
+	newest := strings.Split(buf.String(), "\t")[0]
+	buf.Reset()
+	if err := RestoreCmd(testFile, newest, true, &buf); err != nil {
+		t.Fatalf("RestoreCmd error: %v", err)
+	}
+	content, _ = os.ReadFile(testFile)
+	if strings.Count(string(content), "2026-") != 4 {
+		t.Errorf("undoing the restore should bring back all entries, got:\n%s", content)
+	}
+
+	if err := RestoreCmd(testFile, "1999", false, &buf); err == nil {
+		t.Error("expected an error for an unknown snapshot")
+	}
+}
+
+func TestBackup_disabled(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	t.Setenv("CHORES_BACKUP_DIR", filepath.Join(tmpDir, "backups"))
+	t.Setenv("CHORES_BACKUPS", "0")
+
+	if err := os.WriteFile(testFile, []byte("## Trash\n> 2d\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := DoneCmd(testFile, "Trash", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), &bytes.Buffer{}); err != nil {
+		t.Fatalf("DoneCmd error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "backups")); !os.IsNotExist(err) {
+		t.Errorf("no backups should be taken, got: %v", err)
+	}
+}
+
+func TestBackup_homeDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("CHORES_BACKUP_DIR", "~/backups")
+
+	if err := os.WriteFile(testFile, []byte("## Trash\n> 2d\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := DoneCmd(testFile, "Trash", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), &bytes.Buffer{}); err != nil {
+		t.Fatalf("DoneCmd error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "backups")); err != nil {
+		t.Errorf("expected backups under the home directory: %v", err)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	want := "--- old\n+++ new\n" +
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n" +
+		"@@ -9,3 +9,4 @@\n i\n j\n k\n+l\n"
+	if got := unifiedDiff(from, to, "old", "new"); got != want {
+		t.Errorf("unifiedDiff =\n%s\nwant\n%s", got, want)
+	}
+	if got := unifiedDiff(from, from, "old", "new"); got != "" {
+		t.Errorf("unifiedDiff of equal texts = %q, want empty", got)
+	}
+	if got := unifiedDiff("", "a\n", "old", "new"); got != "--- old\n+++ new\n@@ -0,0 +1,1 @@\n+a\n" {
+		t.Errorf("unifiedDiff from empty = %q", got)
+	}
+}
@@ -3,6 +3,9 @@ package cli
 import (
 	"fmt"
 	"io"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/kusha/chores-md/internal/model"
@@ -10,20 +13,72 @@ import (
 	"github.com/kusha/chores-md/internal/schedule"
 )
 
+// ShowOptions controls what ShowWithOptions displays.
+type ShowOptions struct {
+	Routines bool   // Collapse the members of each routine into a single entry
+	Details  bool   // List checklist steps under each chore
+	Cache    string // Directory to cache parse results in ("" for the cache setting)
+	Color    string // "auto", "always" or "never" ("" for the color setting)
+	Format   string // "text" or "tsv" ("" for the format setting)
+}
+
+// routineUnit is a routine collapsed into one entry of the show output.
+type routineUnit struct {
+	Routine model.Routine
+	Status  schedule.Status        // Status of the most urgent member
+	Members []schedule.ChoreStatus // Members shown by show, most urgent first
+}
+
 func ShowCmd(file string, now time.Time, out io.Writer) error {
-	result, err := parser.ParseFile(file)
+	return ShowWithOptions(file, now, ShowOptions{}, out)
+}
+
+func ShowWithOptions(file string, now time.Time, opts ShowOptions, out io.Writer) error {
+	var err error
+	if opts.Cache == "" {
+		if opts.Cache, err = pathSetting("cache"); err != nil {
+			return err
+		}
+	}
+	result, err := parser.ParseFileCached(file, opts.Cache)
 	if err != nil {
 		return err
 	}
 
-	statuses := schedule.Calculate(result.Chores, result.Completions, now)
+	if opts.Color == "" {
+		if opts.Color, err = setting("color", result.FrontMatter); err != nil {
+			return err
+		}
+	}
+	if opts.Format == "" {
+		if opts.Format, err = setting("format", result.FrontMatter); err != nil {
+			return err
+		}
+	}
+
+	statuses := schedule.CalculateWithUsage(result.Chores, result.Completions, result.Usages, now)
 	schedule.SortByUrgency(statuses)
+	// TSV is read by scripts, which a warning line would break; lint and
+	// the other commands still report conflict copies.
+	if opts.Format == "tsv" {
+		printTSV(out, statuses)
+		return nil
+	}
+	warnConflicts(result, out)
+	color := opts.Color == "always" || (opts.Color == "auto" && isTerminal(out) && os.Getenv("NO_COLOR") == "")
+
+	var units []routineUnit
+	if opts.Routines {
+		statuses, units = collapseRoutines(statuses, result.Routines)
+	}
 
-	var overdue, dueToday, upcoming, clear []schedule.ChoreStatus
+	var overdue, grace, dueToday, upcoming, clear []schedule.ChoreStatus
 	for _, cs := range statuses {
 		switch cs.Status {
 		case schedule.StatusOverdue:
 			overdue = append(overdue, cs)
+		case schedule.StatusGrace:
+			grace = append(grace, cs)
 		case schedule.StatusDueToday:
 			dueToday = append(dueToday, cs)
 		case schedule.StatusUpcoming:
@@ -33,9 +88,9 @@ func ShowCmd(file string, now time.Time, out io.Writer) error {
 		}
 	}
 
-	if len(overdue) > 0 {
-		fmt.Fprintln(out, "OVERDUE")
-		var totalMinutes int
+	if len(overdue) > 0 || hasUnits(units, schedule.StatusOverdue) {
+		printHeading(out, "OVERDUE", "1;31", color)
+		totalMinutes := printRoutines(out, units, schedule.StatusOverdue)
 		for _, cs := range overdue {
 			durationStr := ""
 			if cs.Chore.DurationMinutes > 0 {
@@ -45,9 +100,15 @@ func ShowCmd(file string, now time.Time, out io.Writer) error {
 			if cs.DaysOverdue == schedule.NeverDoneSentinel {
 				fmt.Fprintf(out, "  %s %s(never done)\n", cs.Chore.Name, durationStr)
 				fmt.Fprintln(out, "    Last: never")
+			} else if detail := timingDetail(cs); detail != "" {
+				fmt.Fprintf(out, "  %s %s(%s)\n", cs.Chore.Name, durationStr, detail)
+				fmt.Fprintf(out, "    Last: %s\n", formatLast(cs))
 			} else {
 				fmt.Fprintf(out, "  %s %s(%d days overdue)\n", cs.Chore.Name, durationStr, cs.DaysOverdue)
-				fmt.Fprintf(out, "    Last: %s\n", cs.LastDone.Format("2006-01-02"))
+				fmt.Fprintf(out, "    Last: %s\n", formatLast(cs))
+			}
+			if opts.Details {
+				printSteps(out, cs)
 			}
 		}
 		if totalMinutes > 0 {
@@ -56,17 +117,50 @@ func ShowCmd(file string, now time.Time, out io.Writer) error {
 		fmt.Fprintln(out)
 	}
 
-	if len(dueToday) > 0 {
-		fmt.Fprintln(out, "DUE TODAY")
-		var totalMinutes int
+	if len(grace) > 0 || hasUnits(units, schedule.StatusGrace) {
+		printHeading(out, "DUE (grace)", "33", color)
+		totalMinutes := printRoutines(out, units, schedule.StatusGrace)
+		for _, cs := range grace {
+			durationStr := ""
+			if cs.Chore.DurationMinutes > 0 {
+				durationStr = fmt.Sprintf("(~%s) ", model.FormatDuration(cs.Chore.DurationMinutes))
+				totalMinutes += cs.Chore.DurationMinutes
+			}
+			if detail := timingDetail(cs); detail != "" {
+				fmt.Fprintf(out, "  %s %s(%s)\n", cs.Chore.Name, durationStr, detail)
+			} else {
+				left := cs.Chore.GraceDays - cs.DaysOverdue
+				fmt.Fprintf(out, "  %s %s(%s late, %s of grace left)\n", cs.Chore.Name, durationStr, pluralDays(cs.DaysOverdue), pluralDays(left))
+			}
+			fmt.Fprintf(out, "    Last: %s\n", formatLast(cs))
+			if opts.Details {
+				printSteps(out, cs)
+			}
+		}
+		if totalMinutes > 0 {
+			fmt.Fprintf(out, "  Total: %s\n", model.FormatDuration(totalMinutes))
+		}
+		fmt.Fprintln(out)
+	}
+
+	if len(dueToday) > 0 || hasUnits(units, schedule.StatusDueToday) {
+		printHeading(out, "DUE TODAY", "1;33", color)
+		totalMinutes := printRoutines(out, units, schedule.StatusDueToday)
 		for _, cs := range dueToday {
 			durationStr := ""
 			if cs.Chore.DurationMinutes > 0 {
 				durationStr = fmt.Sprintf("(~%s) ", model.FormatDuration(cs.Chore.DurationMinutes))
 				totalMinutes += cs.Chore.DurationMinutes
 			}
-			fmt.Fprintf(out, "  %s %s\n", cs.Chore.Name, durationStr)
-			fmt.Fprintf(out, "    Last: %s\n", cs.LastDone.Format("2006-01-02"))
+			if detail := timingDetail(cs); detail != "" {
+				fmt.Fprintf(out, "  %s %s(%s)\n", cs.Chore.Name, durationStr, detail)
+			} else {
+				fmt.Fprintf(out, "  %s %s\n", cs.Chore.Name, durationStr)
+			}
+			fmt.Fprintf(out, "    Last: %s\n", formatLast(cs))
+			if opts.Details {
+				printSteps(out, cs)
+			}
 		}
 		if totalMinutes > 0 {
 			fmt.Fprintf(out, "  Total: %s\n", model.FormatDuration(totalMinutes))
@@ -74,21 +168,32 @@ func ShowCmd(file string, now time.Time, out io.Writer) error {
 		fmt.Fprintln(out)
 	}
 
-	if len(upcoming) > 0 {
-		fmt.Fprintln(out, "UPCOMING (7 days)")
-		var totalMinutes int
+	if len(upcoming) > 0 || hasUnits(units, schedule.StatusUpcoming) {
+		printHeading(out, "UPCOMING (7 days)", "36", color)
+		totalMinutes := printRoutines(out, units, schedule.StatusUpcoming)
 		for _, cs := range upcoming {
 			durationStr := ""
 			if cs.Chore.DurationMinutes > 0 {
 				durationStr = fmt.Sprintf("(~%s) ", model.FormatDuration(cs.Chore.DurationMinutes))
 				totalMinutes += cs.Chore.DurationMinutes
 			}
-			fmt.Fprintf(out, "  %s %s(due in %d day", cs.Chore.Name, durationStr, cs.DaysUntil)
-			if cs.DaysUntil != 1 {
-				fmt.Fprint(out, "s")
+			eligibleStr := ""
+			if cs.Eligible {
+				eligibleStr = "eligible, "
+			}
+			if detail := timingDetail(cs); detail != "" {
+				fmt.Fprintf(out, "  %s %s(%s)\n", cs.Chore.Name, durationStr, detail)
+			} else {
+				fmt.Fprintf(out, "  %s %s(%sdue in %d day", cs.Chore.Name, durationStr, eligibleStr, cs.DaysUntil)
+				if cs.DaysUntil != 1 {
+					fmt.Fprint(out, "s")
+				}
+				fmt.Fprintln(out, ")")
+			}
+			fmt.Fprintf(out, "    Last: %s\n", formatLast(cs))
+			if opts.Details {
+				printSteps(out, cs)
 			}
-			fmt.Fprintln(out, ")")
-			fmt.Fprintf(out, "    Last: %s\n", cs.LastDone.Format("2006-01-02"))
 		}
 		if totalMinutes > 0 {
 			fmt.Fprintf(out, "  Total: %s\n", model.FormatDuration(totalMinutes))
@@ -96,17 +201,24 @@ func ShowCmd(file string, now time.Time, out io.Writer) error {
 		fmt.Fprintln(out)
 	}
 
-	if len(clear) > 0 {
-		fmt.Fprintln(out, "ALL CLEAR")
-		var totalMinutes int
+	if len(clear) > 0 || hasUnits(units, schedule.StatusClear) {
+		printHeading(out, "ALL CLEAR", "32", color)
+		totalMinutes := printRoutines(out, units, schedule.StatusClear)
 		for _, cs := range clear {
 			durationStr := ""
 			if cs.Chore.DurationMinutes > 0 {
 				durationStr = fmt.Sprintf("(~%s) ", model.FormatDuration(cs.Chore.DurationMinutes))
 				totalMinutes += cs.Chore.DurationMinutes
 			}
-			fmt.Fprintf(out, "  %s %s(due in %d days)\n", cs.Chore.Name, durationStr, cs.DaysUntil)
-			fmt.Fprintf(out, "    Last: %s\n", cs.LastDone.Format("2006-01-02"))
+			if detail := timingDetail(cs); detail != "" {
+				fmt.Fprintf(out, "  %s %s(%s)\n", cs.Chore.Name, durationStr, detail)
+			} else {
+				fmt.Fprintf(out, "  %s %s(due in %d days)\n", cs.Chore.Name, durationStr, cs.DaysUntil)
+			}
+			fmt.Fprintf(out, "    Last: %s\n", formatLast(cs))
+			if opts.Details {
+				printSteps(out, cs)
+			}
 		}
 		if totalMinutes > 0 {
 			fmt.Fprintf(out, "  Total: %s\n", model.FormatDuration(totalMinutes))
@@ -116,3 +228,207 @@ func ShowCmd(file string, now time.Time, out io.Writer) error {
 
 	return nil
 }
+
+// printHeading prints the heading of a section of show, in the given ANSI
+// color if color is set.
+func printHeading(out io.Writer, title string, code string, color bool) {
+	if color {
+		fmt.Fprintf(out, "\x1b[%sm%s\x1b[0m\n", code, title)
+		return
+	}
+	fmt.Fprintln(out, title)
+}
+
+// isTerminal reports whether out is a terminal, where color is used by
+// default.
+func isTerminal(out io.Writer) bool {
+	f, ok := out.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// statusNames name the statuses of the chores show lists in its TSV output.
+var statusNames = map[schedule.Status]string{
+	schedule.StatusOverdue:  "overdue",
+	schedule.StatusGrace:    "grace",
+	schedule.StatusDueToday: "due-today",
+	schedule.StatusUpcoming: "upcoming",
+	schedule.StatusClear:    "clear",
+}
+
+// printTSV prints the chores show lists as tab-separated values for
+// scripts, most urgent first: status, name, days until due (negative when
+// late, empty if never done) and last completion.
+func printTSV(out io.Writer, statuses []schedule.ChoreStatus) {
+	fmt.Fprintln(out, "status\tchore\tdue_in_days\tlast")
+	for _, cs := range statuses {
+		name, ok := statusNames[cs.Status]
+		if !ok {
+			continue
+		}
+		days := strconv.Itoa(cs.DaysUntil)
+		switch {
+		case cs.DaysOverdue == schedule.NeverDoneSentinel:
+			days = ""
+		case cs.Status == schedule.StatusOverdue || cs.Status == schedule.StatusGrace:
+			days = strconv.Itoa(-cs.DaysOverdue)
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", name, cs.Chore.Name, days, formatLast(cs))
+	}
+}
+
+// collapseRoutines removes the members of each routine from statuses and
+// returns them grouped into units, placed at their most urgent member. Only
+// members that show displays are collected, and a chore listed in several
+// routines goes to the first one.
+func collapseRoutines(statuses []schedule.ChoreStatus, routines []model.Routine) ([]schedule.ChoreStatus, []routineUnit) {
+	taken := make([]bool, len(statuses))
+	var units []routineUnit
+	for _, routine := range routines {
+		unit := routineUnit{Routine: routine}
+		for i, cs := range statuses {
+			if taken[i] || cs.Status > schedule.StatusClear || !isMember(routine, cs.Chore.Name) {
+				continue
+			}
+			taken[i] = true
+			unit.Members = append(unit.Members, cs)
+		}
+		if len(unit.Members) > 0 {
+			unit.Status = unit.Members[0].Status
+			units = append(units, unit)
+		}
+	}
+
+	var rest []schedule.ChoreStatus
+	for i, cs := range statuses {
+		if !taken[i] {
+			rest = append(rest, cs)
+		}
+	}
+	return rest, units
+}
+
+func isMember(routine model.Routine, name string) bool {
+	for _, member := range routine.Members {
+		if strings.EqualFold(member, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasUnits(units []routineUnit, status schedule.Status) bool {
+	for _, unit := range units {
+		if unit.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// printRoutines prints the routines placed in the section for status and
+// returns their summed duration in minutes.
+func printRoutines(out io.Writer, units []routineUnit, status schedule.Status) int {
+	var totalMinutes int
+	for _, unit := range units {
+		if unit.Status != status {
+			continue
+		}
+		var minutes, due int
+		var names []string
+		for _, cs := range unit.Members {
+			minutes += cs.Chore.DurationMinutes
+			names = append(names, cs.Chore.Name)
+			if cs.Status <= schedule.StatusDueToday {
+				due++
+			}
+		}
+
+		durationStr := ""
+		if minutes > 0 {
+			durationStr = fmt.Sprintf("(~%s) ", model.FormatDuration(minutes))
+		}
+		detail := fmt.Sprintf("%d chores", len(unit.Members))
+		if len(unit.Members) == 1 {
+			detail = "1 chore"
+		}
+		if due > 0 {
+			detail += fmt.Sprintf(", %d due", due)
+		}
+		fmt.Fprintf(out, "  Routine: %s %s(%s)\n", unit.Routine.Name, durationStr, detail)
+		fmt.Fprintf(out, "    %s\n", strings.Join(names, ", "))
+		totalMinutes += minutes
+	}
+	return totalMinutes
+}
+
+// printSteps lists a chore's checklist, marking the steps done since its
+// last full completion.
+func printSteps(out io.Writer, cs schedule.ChoreStatus) {
+	done := make(map[int]bool)
+	for _, step := range cs.StepsDone {
+		done[step] = true
+	}
+	for i, step := range cs.Chore.Steps {
+		mark := " "
+		if done[i+1] {
+			mark = "x"
+		}
+		fmt.Fprintf(out, "    [%s] %d. %s\n", mark, i+1, step)
+	}
+}
+
+// timingDetail describes when a usage, quota or sub-daily chore is due, or
+// returns "" for chores scheduled in whole days.
+func timingDetail(cs schedule.ChoreStatus) string {
+	switch {
+	case cs.Chore.UsageLimit > 0:
+		usage := fmt.Sprintf("%d/%d %s", cs.UsageSince, cs.Chore.UsageLimit, cs.Chore.UsageUnit)
+		switch {
+		case cs.NoEstimate:
+			return usage
+		case cs.Status == schedule.StatusOverdue || cs.Status == schedule.StatusGrace:
+			return fmt.Sprintf("%s, ~%s overdue", usage, pluralDays(cs.DaysOverdue))
+		case cs.Status == schedule.StatusDueToday:
+			return usage + ", due today"
+		default:
+			return fmt.Sprintf("%s, due in ~%s", usage, pluralDays(cs.DaysUntil))
+		}
+	case cs.Chore.QuotaCount > 0:
+		period := map[string]string{"w": "week", "m": "month", "y": "year"}[cs.Chore.QuotaPeriod]
+		return fmt.Sprintf("%d/%d this %s, %s left", cs.QuotaDone, cs.Chore.QuotaCount, period, pluralDays(cs.DaysLeft))
+	case cs.Chore.FrequencyMins > 0 && cs.Status == schedule.StatusOverdue:
+		return model.FormatDuration(cs.MinutesOverdue) + " overdue"
+	case cs.Chore.FrequencyMins > 0 && cs.Status == schedule.StatusGrace:
+		return model.FormatDuration(cs.MinutesOverdue) + " late"
+	case cs.Chore.FrequencyMins > 0 && cs.MinutesUntil == 0:
+		return "due now"
+	case cs.Chore.FrequencyMins > 0:
+		return "due in " + model.FormatDuration(cs.MinutesUntil)
+	}
+	return ""
+}
+
+// formatLast formats the last completion date, or "never" for a chore that
+// has not been done yet (e.g., a one-off task with a deadline). Sub-daily
+// chores include the time of day.
+func formatLast(cs schedule.ChoreStatus) string {
+	if cs.LastDone == nil {
+		return "never"
+	}
+	if cs.Chore.FrequencyMins > 0 {
+		return cs.LastDone.Format("2006-01-02 15:04")
+	}
+	return cs.LastDone.Format("2006-01-02")
+}
+
+// pluralDays formats a day count as "1 day" or "N days".
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
@@ -30,6 +30,53 @@ func TestShowCmd(t *testing.T) {
 ## Never Done Task
 > 1d
 
+## Grace Task
+> 1w grace 3d
+
+## Window Task
+> 5-9d
+
+## Summer Task
+> 1w 2h in may-sep
+
+## One-off Task
+> once by 2026-02-12
+
+## Paused Task
+> 1d paused
+
+## Feed Cat
+> 2x/d
+
+## Medication
+> 8h
+
+## Vacuum
+> 3x/w
+
+## Dishwasher Filter
+> 30 cycles
+
+## Clean Mower Deck
+after: Mow Lawn within 2d
+
+## Write Down Filter Size
+after: Change Furnace Filter
+
+## Mow Lawn
+> 2w
+
+## Change Furnace Filter
+> 3m
+
+2026-02-01 Grace Task
+2026-02-10 03:00 Feed Cat
+2026-02-09 Vacuum
+2026-02-09 Mow Lawn
+2026-02-01 Dishwasher Filter
+2026-02-05 Dishwasher Filter +12 cycles
+2026-02-10 02:30 Medication
+2026-02-04 Window Task
 2026-01-31 Overdue Task
 2026-02-03 Due Today Task
 2026-02-05 Upcoming Task
@@ -55,6 +102,18 @@ func TestShowCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("contains_grace_section", func(t *testing.T) {
+		if !strings.Contains(output, "DUE (grace)") {
+			t.Error("missing DUE (grace) section")
+		}
+		if !strings.Contains(output, "Grace Task (2 days late, 1 day of grace left)") {
+			t.Errorf("missing grace details, got:\n%s", output)
+		}
+		if strings.Index(output, "DUE (grace)") > strings.Index(output, "DUE TODAY") {
+			t.Error("DUE (grace) should come before DUE TODAY")
+		}
+	})
+
 	t.Run("contains_due_today_section", func(t *testing.T) {
 		if !strings.Contains(output, "DUE TODAY") {
 			t.Error("missing DUE TODAY section")
@@ -70,6 +129,63 @@ func TestShowCmd(t *testing.T) {
 		}
 	})
 
+	t.Run("eligible_window", func(t *testing.T) {
+		if !strings.Contains(output, "Window Task (eligible, due in 3 days)") {
+			t.Errorf("range chore inside its window should be eligible, got:\n%s", output)
+		}
+	})
+
+	t.Run("once_with_deadline", func(t *testing.T) {
+		if !strings.Contains(output, "One-off Task (due in 2 days)\n    Last: never") {
+			t.Errorf("one-off chore should be upcoming by its deadline, got:\n%s", output)
+		}
+	})
+
+	t.Run("sub_daily", func(t *testing.T) {
+		if !strings.Contains(output, "Feed Cat (due in 3h)\n    Last: 2026-02-10 03:00") {
+			t.Errorf("sub-daily chore should be due in hours, got:\n%s", output)
+		}
+		if !strings.Contains(output, "Medication (1h 30m overdue)") {
+			t.Errorf("sub-daily chore should be overdue in hours, got:\n%s", output)
+		}
+	})
+
+	t.Run("quota_progress", func(t *testing.T) {
+		if !strings.Contains(output, "Vacuum (1/3 this week, 6 days left)") {
+			t.Errorf("quota chore should show progress, got:\n%s", output)
+		}
+	})
+
+	t.Run("usage_estimate", func(t *testing.T) {
+		if !strings.Contains(output, "Dishwasher Filter (12/30 cycles, due in ~14 days)") {
+			t.Errorf("usage chore should show usage and estimate, got:\n%s", output)
+		}
+	})
+
+	t.Run("follow_up", func(t *testing.T) {
+		if !strings.Contains(output, "Clean Mower Deck (due in 1 day)") {
+			t.Errorf("triggered follow-up should be shown, got:\n%s", output)
+		}
+		if strings.Contains(output, "Write Down Filter Size") {
+			t.Errorf("untriggered follow-up should be hidden, got:\n%s", output)
+		}
+	})
+
+	t.Run("paused_hidden", func(t *testing.T) {
+		if strings.Contains(output, "Paused Task") {
+			t.Errorf("paused chore should not be shown, got:\n%s", output)
+		}
+	})
+
+	t.Run("dormant_hidden", func(t *testing.T) {
+		if strings.Contains(output, "Summer Task") {
+			t.Errorf("out-of-season chore should not be shown, got:\n%s", output)
+		}
+		if strings.Contains(output, "Total: 2h") {
+			t.Errorf("out-of-season chore should not count toward totals, got:\n%s", output)
+		}
+	})
+
 	t.Run("contains_clear_section", func(t *testing.T) {
 		if !strings.Contains(output, "ALL CLEAR") {
 			t.Error("missing ALL CLEAR section")
@@ -122,3 +238,203 @@ func TestShowCmd_equal_urgency_sort(t *testing.T) {
 		t.Errorf("Alpha Task should appear before Zebra Task (alphabetical tie-breaker)")
 	}
 }
+
+func TestShowWithOptions_routines(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	content := `## Vacuum
+> 1w 45m
+
+## Mop Floors
+> 1w 30m
+
+## Dust Shelves
+> 2w 15m
+
+## Take Out Trash
+> 2d
+
+# Routine: Saturday
+- Vacuum
+- Mop Floors
+- Dust Shelves
+
+2026-02-01 Vacuum
+2026-02-08 Mop Floors
+2026-02-08 Dust Shelves
+2026-02-09 Take Out Trash
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ShowWithOptions(testFile, now, ShowOptions{Routines: true}, &buf); err != nil {
+		t.Fatalf("ShowWithOptions error: %v", err)
+	}
+
+	output := buf.String()
+	overdue, rest, ok := strings.Cut(output, "\nUPCOMING (7 days)\n")
+	if !ok {
+		t.Fatalf("missing UPCOMING section, got:\n%s", output)
+	}
+	if !strings.Contains(overdue, "Routine: Saturday (~1h 30m) (3 chores, 1 due)\n    Vacuum, Mop Floors, Dust Shelves\n  Total: 1h 30m") {
+		t.Errorf("routine should be collapsed under its most urgent member, got:\n%s", output)
+	}
+	if strings.Contains(rest, "Mop Floors") {
+		t.Errorf("members should not be listed individually, got:\n%s", output)
+	}
+	if !strings.Contains(rest, "Take Out Trash") {
+		t.Errorf("chores outside routines should still be listed, got:\n%s", output)
+	}
+}
+
+func TestShowWithOptions_details(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	content := `## Bathroom
+> 1w
+- [ ] Scrub toilet
+- [ ] Clean mirror
+
+2026-02-01 Bathroom
+2026-02-09 Bathroom [steps 2]
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ShowWithOptions(testFile, now, ShowOptions{Details: true}, &buf); err != nil {
+		t.Fatalf("ShowWithOptions error: %v", err)
+	}
+
+	want := "OVERDUE\n  Bathroom (2 days overdue)\n    Last: 2026-02-01\n    [ ] 1. Scrub toilet\n    [x] 2. Clean mirror\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("partially done chore should stay overdue with its progress, got:\n%s", buf.String())
+	}
+}
+
+func TestShowWithOptions_cache(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	cacheDir := filepath.Join(tmpDir, "cache")
+
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+	if err := os.WriteFile(testFile, []byte("## Trash\n> 2d\n\n2026-02-09 Trash\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var uncached bytes.Buffer
+	if err := ShowWithOptions(testFile, now, ShowOptions{}, &uncached); err != nil {
+		t.Fatalf("ShowWithOptions error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		if err := ShowWithOptions(testFile, now, ShowOptions{Cache: cacheDir}, &buf); err != nil {
+			t.Fatalf("ShowWithOptions error: %v", err)
+		}
+		if buf.String() != uncached.String() {
+			t.Errorf("run %d: cached output =\n%s\nwant\n%s", i+1, buf.String(), uncached.String())
+		}
+	}
+
+	if entries, _ := os.ReadDir(cacheDir); len(entries) != 1 {
+		t.Errorf("expected one cache file, got %d", len(entries))
+	}
+}
+
+func TestShowCmd_cacheSetting(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	cacheDir := filepath.Join(tmpDir, "cache")
+	t.Setenv("CHORES_CACHE", cacheDir)
+
+	if err := os.WriteFile(testFile, []byte("## Trash\n> 2d\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := ShowCmd(testFile, time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC), &bytes.Buffer{}); err != nil {
+		t.Fatalf("ShowCmd error: %v", err)
+	}
+	if entries, _ := os.ReadDir(cacheDir); len(entries) != 1 {
+		t.Errorf("expected one cache file, got %d", len(entries))
+	}
+}
+
+func TestShowWithOptions_format(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	content := "---\nformat: tsv\n---\n## Trash\n> 2d\n\n## Plants\n> 1w\n\n## Windows\n> 1m\n\n2026-02-05 Trash\n2026-02-08 Plants\n"
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	// The front matter asks for TSV.
+	var buf bytes.Buffer
+	if err := ShowCmd(testFile, now, &buf); err != nil {
+		t.Fatalf("ShowCmd error: %v", err)
+	}
+	want := "status\tchore\tdue_in_days\tlast\n" +
+		"overdue\tTrash\t-3\t2026-02-05\n" +
+		"overdue\tWindows\t\tnever\n" +
+		"upcoming\tPlants\t5\t2026-02-08\n"
+	if buf.String() != want {
+		t.Errorf("tsv output =\n%q\nwant\n%q", buf.String(), want)
+	}
+
+	// The environment overrides the front matter.
+	t.Setenv("CHORES_FORMAT", "text")
+	buf.Reset()
+	if err := ShowWithOptions(testFile, now, ShowOptions{Color: "always"}, &buf); err != nil {
+		t.Fatalf("ShowWithOptions error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "\x1b[1;31mOVERDUE\x1b[0m\n") {
+		t.Errorf("expected a colored heading, got:\n%q", buf.String())
+	}
+
+	buf.Reset()
+	if err := ShowCmd(testFile, now, &buf); err != nil {
+		t.Fatalf("ShowCmd error: %v", err)
+	}
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("output that is not a terminal should not be colored, got:\n%q", buf.String())
+	}
+}
+
+func TestShowWithOptions_tsvConflictCopy(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+	copyFile := filepath.Join(tmpDir, "chores.sync-conflict-20260203-101530-ABCDEFG.md")
+	for path, content := range map[string]string{
+		testFile: "## Trash\n> 2d\n\n2026-02-09 Trash\n",
+		copyFile: "## Trash\n> 2d\n\n2026-02-10 Trash\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	now := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+	if err := ShowWithOptions(testFile, now, ShowOptions{Format: "tsv"}, &buf); err != nil {
+		t.Fatalf("ShowWithOptions error: %v", err)
+	}
+	want := "status\tchore\tdue_in_days\tlast\nupcoming\tTrash\t1\t2026-02-09\n"
+	if buf.String() != want {
+		t.Errorf("tsv output =\n%q\nwant\n%q", buf.String(), want)
+	}
+
+	// The text output still warns.
+	buf.Reset()
+	if err := ShowWithOptions(testFile, now, ShowOptions{Format: "text"}, &buf); err != nil {
+		t.Fatalf("ShowWithOptions error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Warning: ") {
+		t.Errorf("expected a conflict warning, got:\n%s", buf.String())
+	}
+}
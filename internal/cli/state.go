@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/model"
+)
+
+func PauseCmd(file string, choreName string, out io.Writer) error {
+	return setState(file, choreName, model.StatePaused, out)
+}
+
+func ResumeCmd(file string, choreName string, out io.Writer) error {
+	return setState(file, choreName, model.StateActive, out)
+}
+
+func ArchiveCmd(file string, choreName string, out io.Writer) error {
+	return setState(file, choreName, model.StateArchived, out)
+}
+
+// setState rewrites the chore's "> " line so that it carries the given state
+// keyword, leaving the rest of the file it is defined in untouched.
+func setState(file string, choreName string, state model.State, out io.Writer) error {
+	result, unlock, err := parseForUpdate(file, out)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	chore, found := findChore(result.Chores, choreName)
+	if !found {
+		return fmt.Errorf("chore not found: %q", choreName)
+	}
+
+	// The state is kept on the "> " line, which a chore that only follows
+	// another one through "after:" does not have.
+	if chore.FrequencyLine == 0 {
+		return fmt.Errorf("chore %q has no \"> \" line to mark its state on (it only follows %q)", chore.Name, chore.AfterChore)
+	}
+
+	content, err := readSource(result, chore.Source)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	idx := chore.FrequencyLine - 1
+	line := strings.TrimRight(lines[idx], "\r")
+	eol := lines[idx][len(line):]
+
+	var fields []string
+	for _, f := range strings.Fields(strings.TrimPrefix(line, ">")) {
+		if f != "paused" && f != "archived" {
+			fields = append(fields, f)
+		}
+	}
+
+	var verb, command string
+	switch state {
+	case model.StatePaused:
+		fields = append(fields, "paused")
+		verb, command = "Paused", "pause"
+	case model.StateArchived:
+		fields = append(fields, "archived")
+		verb, command = "Archived", "archive"
+	default:
+		verb, command = "Resumed", "resume"
+	}
+
+	lines[idx] = "> " + strings.Join(fields, " ") + eol
+
+	if err := writeAtomic(chore.Source, []byte(strings.Join(lines, "\n"))); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "%s: %q\n", verb, chore.Name)
+	commitUpdate(file, result, command+": "+chore.Name, out)
+	return nil
+}
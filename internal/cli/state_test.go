@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStateCmds(t *testing.T) {
+	baseContent := "## Wash Car\r\n> 2w 45m\r\n\r\nAt the car wash.\r\n\r\n2026-01-20 Wash Car\r\n"
+
+	tests := []struct {
+		name     string
+		cmd      func(file, name string, out *bytes.Buffer) error
+		start    string
+		wantLine string
+		wantOut  string
+	}{
+		{"pause", func(f, n string, o *bytes.Buffer) error { return PauseCmd(f, n, o) }, baseContent, "> 2w 45m paused\r\n", `Paused: "Wash Car"`},
+		{"archive_paused", func(f, n string, o *bytes.Buffer) error { return ArchiveCmd(f, n, o) },
+			strings.Replace(baseContent, "45m", "45m paused", 1), "> 2w 45m archived\r\n", `Archived: "Wash Car"`},
+		{"resume", func(f, n string, o *bytes.Buffer) error { return ResumeCmd(f, n, o) },
+			strings.Replace(baseContent, "45m", "paused 45m", 1), "> 2w 45m\r\n", `Resumed: "Wash Car"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "chores.md")
+			if err := os.WriteFile(testFile, []byte(tt.start), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := tt.cmd(testFile, "wash car", &buf); err != nil {
+				t.Fatalf("command error: %v", err)
+			}
+
+			content, _ := os.ReadFile(testFile)
+			if !strings.Contains(string(content), "## Wash Car\r\n"+tt.wantLine+"\r\nAt the car wash.") {
+				t.Errorf("frequency line should be %q, got:\n%q", tt.wantLine, string(content))
+			}
+			if !strings.Contains(string(content), "2026-01-20 Wash Car") {
+				t.Errorf("history should be kept, got:\n%s", string(content))
+			}
+			if !strings.Contains(buf.String(), tt.wantOut) {
+				t.Errorf("output = %q, want %q", buf.String(), tt.wantOut)
+			}
+		})
+	}
+
+	t.Run("unknown_chore", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := PauseCmd(testFile, "Sell Car", &buf); err == nil {
+			t.Fatal("expected error for unknown chore")
+		}
+	})
+}
+
+func TestPauseCmd_included(t *testing.T) {
+	dir := t.TempDir()
+	mainFile := filepath.Join(dir, "chores.md")
+	choresFile := filepath.Join(dir, "garden.md")
+	if err := os.WriteFile(mainFile, []byte("<!-- include: garden.md -->\n2026-02-01 Mow Lawn\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := os.WriteFile(choresFile, []byte("## Mow Lawn\n> 1w 45m\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := PauseCmd(mainFile, "Mow Lawn", &buf); err != nil {
+		t.Fatalf("PauseCmd error: %v", err)
+	}
+
+	got, _ := os.ReadFile(choresFile)
+	if string(got) != "## Mow Lawn\n> 1w 45m paused\n" {
+		t.Errorf("chore should be paused in the file defining it, got:\n%s", got)
+	}
+}
+
+func TestStateCmds_afterOnly(t *testing.T) {
+	content := "## Mow Lawn\n> 1w\n\n## Clean Mower Deck\nafter: Mow lawn within 2d\n"
+	cmds := map[string]func(file, name string, out *bytes.Buffer) error{
+		"pause":   func(f, n string, o *bytes.Buffer) error { return PauseCmd(f, n, o) },
+		"resume":  func(f, n string, o *bytes.Buffer) error { return ResumeCmd(f, n, o) },
+		"archive": func(f, n string, o *bytes.Buffer) error { return ArchiveCmd(f, n, o) },
+	}
+
+	for name, cmd := range cmds {
+		t.Run(name, func(t *testing.T) {
+			testFile := filepath.Join(t.TempDir(), "chores.md")
+			if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+
+			err := cmd(testFile, "Clean mower deck", &bytes.Buffer{})
+			if err == nil || !strings.Contains(err.Error(), `only follows "Mow lawn"`) {
+				t.Errorf("expected an error for an after-only chore, got: %v", err)
+			}
+			if got, _ := os.ReadFile(testFile); string(got) != content {
+				t.Errorf("file should be unchanged, got:\n%s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/kusha/chores-md/internal/parser"
+	"github.com/kusha/chores-md/internal/schedule"
+)
+
+// StatsOptions adjusts what StatsWithOptions reads.
+type StatsOptions struct {
+	Archives bool // Read archived entries in place of their summaries
+}
+
+func StatsCmd(file string, out io.Writer) error {
+	return StatsWithOptions(file, StatsOptions{}, out)
+}
+
+// StatsWithOptions prints completion statistics per chore. Archived entries
+// count through their summaries unless opts.Archives asks for the full
+// history, which also classifies them.
+func StatsWithOptions(file string, opts StatsOptions, out io.Writer) error {
+	result, err := parser.ParseFile(file)
+	if err != nil {
+		return err
+	}
+	warnConflicts(result, out)
+
+	completions := result.Completions
+	if opts.Archives {
+		archived, err := archivedCompletions(file)
+		if err != nil {
+			return err
+		}
+		completions = nil
+		for _, c := range result.Completions {
+			if c.Archived == 0 {
+				completions = append(completions, c)
+			}
+		}
+		completions = append(completions, archived...)
+	}
+
+	stats := schedule.Stats(result.Chores, completions)
+	sort.Slice(stats, func(i, j int) bool {
+		return strings.ToLower(stats[i].Chore.Name) < strings.ToLower(stats[j].Chore.Name)
+	})
+
+	for _, st := range stats {
+		fmt.Fprintf(out, "%s\t%d done\t%d on time\t%d in grace\t%d late\n", st.Chore.Name, st.Total, st.OnTime, st.InGrace, st.Late)
+	}
+
+	return nil
+}
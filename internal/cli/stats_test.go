@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStatsCmd(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "chores.md")
+
+	content := `## Vacuum
+> 1w grace 2d
+
+## Dust
+> 1w archived
+
+2026-01-01 Vacuum
+2026-01-08 Vacuum
+2026-01-17 Vacuum
+2026-01-01 Dust
+2026-01-10 Dust
+`
+	if err := os.WriteFile(testFile, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := StatsCmd(testFile, &buf); err != nil {
+		t.Fatalf("StatsCmd error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+
+	if lines[0] != "Dust\t2 done\t0 on time\t0 in grace\t1 late" {
+		t.Errorf("Dust line = %q", lines[0])
+	}
+	if lines[1] != "Vacuum\t3 done\t1 on time\t1 in grace\t0 late" {
+		t.Errorf("Vacuum line = %q", lines[1])
+	}
+}
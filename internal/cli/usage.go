@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// LogUsageCmd records usage for a usage-based chore: an increment such as
+// "+1" or, with reading set, an absolute meter reading such as "= 45210".
+func LogUsageCmd(file string, choreName string, amount int, reading bool, date time.Time, out io.Writer) error {
+	result, unlock, err := parseForUpdate(file, out)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	chore, found := findChore(result.Chores, choreName)
+	if !found {
+		return fmt.Errorf("chore not found: %q", choreName)
+	}
+	if chore.UsageLimit == 0 {
+		return fmt.Errorf("chore %q has no usage limit (e.g., > 200 cups)", chore.Name)
+	}
+	if amount < 0 || (amount == 0 && !reading) {
+		return fmt.Errorf("usage must be positive, got: %d", amount)
+	}
+
+	dateStr := date.Format("2006-01-02")
+	sign := "+"
+	if reading {
+		sign = "= "
+	}
+	entry := fmt.Sprintf("%s %s %s%d %s", dateStr, chore.Name, sign, amount, chore.UsageUnit)
+
+	if err := appendLog(result, "", entry); err != nil {
+		return err
+	}
+
+	if reading {
+		fmt.Fprintf(out, "Reading: %q at %d %s (%s)\n", chore.Name, amount, chore.UsageUnit, dateStr)
+		commitUpdate(file, result, fmt.Sprintf("reading: %s at %d %s (%s)", chore.Name, amount, chore.UsageUnit, dateStr), out)
+	} else {
+		fmt.Fprintf(out, "Usage: %q +%d %s (%s)\n", chore.Name, amount, chore.UsageUnit, dateStr)
+		commitUpdate(file, result, fmt.Sprintf("usage: %s +%d %s (%s)", chore.Name, amount, chore.UsageUnit, dateStr), out)
+	}
+	return nil
+}
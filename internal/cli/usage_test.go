@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogUsageCmd(t *testing.T) {
+	baseContent := `## Dishwasher
+> 30 cycles
+
+## Rotate Tires
+> 8000 km or 1y
+
+## Kitchen Clean
+> 1w`
+
+	date := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("increment_and_reading", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := LogUsageCmd(testFile, "dishwasher", 1, false, date, &buf); err != nil {
+			t.Fatalf("LogUsageCmd error: %v", err)
+		}
+		if err := LogUsageCmd(testFile, "Rotate Tires", 45210, true, date, &buf); err != nil {
+			t.Fatalf("LogUsageCmd error: %v", err)
+		}
+
+		content, _ := os.ReadFile(testFile)
+		if !strings.Contains(string(content), "> 1w\n2026-02-10 Dishwasher +1 cycles\n2026-02-10 Rotate Tires = 45210 km\n") {
+			t.Errorf("usage entries not appended as expected, got:\n%s", string(content))
+		}
+		if !strings.Contains(buf.String(), `Usage: "Dishwasher" +1 cycles`) {
+			t.Errorf("output should confirm usage, got: %s", buf.String())
+		}
+	})
+
+	t.Run("not_usage_chore", func(t *testing.T) {
+		testFile := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(testFile, []byte(baseContent), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+
+		var buf bytes.Buffer
+		err := LogUsageCmd(testFile, "Kitchen Clean", 1, false, date, &buf)
+		if err == nil || !strings.Contains(err.Error(), "no usage limit") {
+			t.Errorf("expected no usage limit error, got: %v", err)
+		}
+	})
+}
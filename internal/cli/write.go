@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kusha/chores-md/internal/parser"
+)
+
+// lockTimeout is how long a command waits for another one to finish
+// changing the chores before giving up.
+var lockTimeout = 10 * time.Second
+
+// parseForUpdate parses the chores at file for a command that changes them.
+// The directory holding them is locked until the returned function is called,
+// so that commands run at the same time take turns instead of overwriting
+// each other's changes. In git sync mode the latest changes are pulled
+// first.
+func parseForUpdate(file string, out io.Writer) (*parser.ParseResult, func(), error) {
+	result, unlock, err := lockAndParse(file, out)
+	if err != nil {
+		return nil, nil, err
+	}
+	warnConflicts(result, out)
+	return result, unlock, nil
+}
+
+// lockAndParse is parseForUpdate without the warnings about conflict copies.
+func lockAndParse(file string, out io.Writer) (*parser.ParseResult, func(), error) {
+	dir := choresDir(file)
+	unlock, err := lockDir(dir, lockTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
+	gitPull(dir, out)
+
+	result, err := parser.ParseFile(file)
+	if err != nil {
+		unlock()
+		return nil, nil, err
+	}
+	return result, unlock, nil
+}
+
+// readSource reads a file of result to rewrite it. It fails if the file was
+// changed since it was parsed, for example by an editor, as the change being
+// made may no longer fit.
+func readSource(result *parser.ParseResult, path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if result.Changed(path, content) {
+		return nil, fmt.Errorf("%s was changed by another program while being updated; try again", path)
+	}
+	return content, nil
+}
+
+// writeAtomic replaces the content of the file at path, after backing up
+// the old content. The new content is written to a temporary file next to
+// it, which is then renamed over it, so that the file is never seen half
+// written. The file keeps its permissions, and a symlink is followed rather
+// than replaced.
+func writeAtomic(path string, data []byte) error {
+	if target, err := filepath.EvalSymlinks(path); err == nil {
+		path = target
+	}
+	if err := backup(path); err != nil {
+		return fmt.Errorf("backing up %s: %w", path, err)
+	}
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// insertEntry inserts log lines after the given line, using the line ending
+// of that line.
+func insertEntry(result *parser.ParseResult, file string, after int, entry string) error {
+	content, err := readSource(result, file)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.SplitAfter(string(content), "\n")
+	if after < 1 || after > len(lines) {
+		return fmt.Errorf("line %d is out of range", after)
+	}
+	eol := "\n"
+	if strings.HasSuffix(lines[after-1], "\r\n") {
+		eol = "\r\n"
+	}
+	if !strings.HasSuffix(lines[after-1], "\n") {
+		lines[after-1] += eol
+	}
+
+	text := strings.Join(lines[:after], "") + strings.ReplaceAll(entry, "\n", eol) + eol + strings.Join(lines[after:], "")
+	return writeAtomic(file, []byte(text))
+}
+
+// appendEntry appends one or more log lines to the end of the file, starting
+// a new line first if the file does not end with one.
+func appendEntry(result *parser.ParseResult, file string, entry string) error {
+	content, err := readSource(result, file)
+	if err != nil {
+		return err
+	}
+
+	entry += "\n"
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		entry = "\n" + entry
+	}
+	return writeAtomic(file, append(content, entry...))
+}
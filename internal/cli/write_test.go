@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteAtomic(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "chores.md")
+	link := filepath.Join(tmpDir, "link.md")
+	if err := os.WriteFile(target, []byte("old\n"), 0600); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	if err := os.Symlink(target, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	if err := writeAtomic(link, []byte("new\n")); err != nil {
+		t.Fatalf("writeAtomic error: %v", err)
+	}
+
+	if info, err := os.Lstat(link); err != nil || info.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("symlink should be kept, got %v, %v", info, err)
+	}
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("stat error: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("permissions = %v, want 0600", info.Mode().Perm())
+	}
+	if content, _ := os.ReadFile(target); string(content) != "new\n" {
+		t.Errorf("content = %q, want %q", content, "new\n")
+	}
+	if entries, _ := os.ReadDir(tmpDir); len(entries) != 2 {
+		t.Errorf("temporary files left behind: %v", entries)
+	}
+}
+
+func TestAppendEntry_changedSinceParse(t *testing.T) {
+	testFile := filepath.Join(t.TempDir(), "chores.md")
+	if err := os.WriteFile(testFile, []byte("## Trash\n> 2d\n"), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+
+	result, unlock, err := parseForUpdate(testFile, io.Discard)
+	if err != nil {
+		t.Fatalf("parseForUpdate error: %v", err)
+	}
+	defer unlock()
+
+	edited := "## Trash\n> 3d\n"
+	if err := os.WriteFile(testFile, []byte(edited), 0644); err != nil {
+		t.Fatalf("failed to edit test file: %v", err)
+	}
+
+	err = appendEntry(result, testFile, "2026-02-01 Trash")
+	if err == nil || !strings.Contains(err.Error(), "changed by another program") {
+		t.Errorf("expected an error for the changed file, got: %v", err)
+	}
+	if content, _ := os.ReadFile(testFile); string(content) != edited {
+		t.Errorf("file should keep the other program's change, got %q", content)
+	}
+}
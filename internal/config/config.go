@@ -0,0 +1,207 @@
+// Package config loads user-level settings and resolves them against
+// flags, environment variables and chore file front matter.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Source describes where a resolved setting came from.
+type Source string
+
+const (
+	SourceFlag        Source = "flag"
+	SourceEnv         Source = "env"
+	SourceUser        Source = "config"
+	SourceFrontMatter Source = "front matter"
+	SourceDefault     Source = "default"
+)
+
+// key describes a single known setting.
+type key struct {
+	env        string   // Environment variable overriding the setting
+	def        string   // Default value
+	allowed    []string // Allowed values (nil means any)
+	numeric    bool     // Whether the value must be a whole number of at least 0
+	fileScoped bool     // Whether front matter may set this key
+}
+
+var keys = map[string]key{
+	"file":       {env: "CHORES_FILE", def: "chores.md"},
+	"user":       {env: "CHORES_USER", def: ""},
+	"color":      {env: "CHORES_COLOR", def: "auto", allowed: []string{"auto", "always", "never"}, fileScoped: true},
+	"format":     {env: "CHORES_FORMAT", def: "text", allowed: []string{"text", "tsv"}, fileScoped: true},
+	"cache":      {env: "CHORES_CACHE", def: ""},
+	"backups":    {env: "CHORES_BACKUPS", def: "10", numeric: true},
+	"backup-dir": {env: "CHORES_BACKUP_DIR", def: ""},
+	"git":        {env: "CHORES_GIT", def: "off", allowed: []string{"off", "commit", "sync"}},
+}
+
+// Keys returns the names of all known settings in sorted order.
+func Keys() []string {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config holds the values stored in the user config file.
+type Config struct {
+	values map[string]string
+}
+
+// DefaultPath returns $XDG_CONFIG_HOME/chores/config, falling back to
+// ~/.config/chores/config when XDG_CONFIG_HOME is unset.
+func DefaultPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "chores", "config"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "chores", "config"), nil
+}
+
+// DefaultBackupDir returns $XDG_STATE_HOME/chores/backups, falling back to
+// ~/.local/state/chores/backups when XDG_STATE_HOME is unset.
+func DefaultBackupDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); dir != "" {
+		return filepath.Join(dir, "chores", "backups"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "chores", "backups"), nil
+}
+
+// Load reads a config file of "key = value" lines. Blank lines and lines
+// starting with # are ignored. A missing file yields an empty config.
+func Load(path string) (*Config, error) {
+	cfg := &Config{values: make(map[string]string)}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key = value", path, lineNum)
+		}
+		name = strings.TrimSpace(name)
+		value = strings.TrimSpace(value)
+		if err := validate(name, value); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, lineNum, err)
+		}
+		cfg.values[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Get returns the value stored for key and whether it was set.
+func (c *Config) Get(name string) (string, bool) {
+	value, ok := c.values[name]
+	return value, ok
+}
+
+// Set stores a value for key after validating it.
+func (c *Config) Set(name, value string) error {
+	if err := validate(name, value); err != nil {
+		return err
+	}
+	c.values[name] = value
+	return nil
+}
+
+// Save writes the config to path, creating parent directories as needed.
+func (c *Config) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	for _, name := range Keys() {
+		if value, ok := c.values[name]; ok {
+			fmt.Fprintf(&sb, "%s = %s\n", name, value)
+		}
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// Resolve returns the effective value of a setting and where it came from.
+// Precedence is flags > environment > user config > front matter > default.
+// Either flags or frontMatter may be nil.
+func Resolve(name string, flags map[string]string, cfg *Config, frontMatter map[string]string) (string, Source, error) {
+	k, ok := keys[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown config key: %q", name)
+	}
+
+	if value, ok := flags[name]; ok {
+		return value, SourceFlag, nil
+	}
+	if value := os.Getenv(k.env); value != "" {
+		return value, SourceEnv, nil
+	}
+	if cfg != nil {
+		if value, ok := cfg.values[name]; ok {
+			return value, SourceUser, nil
+		}
+	}
+	if k.fileScoped {
+		if value, ok := frontMatter[name]; ok {
+			return value, SourceFrontMatter, nil
+		}
+	}
+	return k.def, SourceDefault, nil
+}
+
+func validate(name, value string) error {
+	k, ok := keys[name]
+	if !ok {
+		return fmt.Errorf("unknown config key: %q", name)
+	}
+	if k.numeric {
+		if n, err := strconv.Atoi(value); err != nil || n < 0 {
+			return fmt.Errorf("invalid value %q for %s (expected a number)", value, name)
+		}
+		return nil
+	}
+	if k.allowed == nil {
+		return nil
+	}
+	for _, a := range k.allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q for %s (expected one of: %s)", value, name, strings.Join(k.allowed, ", "))
+}
@@ -0,0 +1,136 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != filepath.Join("/tmp/xdg", "chores", "config") {
+		t.Errorf("DefaultPath() = %q, want under XDG_CONFIG_HOME", path)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("missing_file", func(t *testing.T) {
+		cfg, err := Load(filepath.Join(t.TempDir(), "config"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, ok := cfg.Get("user"); ok {
+			t.Error("missing file should yield empty config")
+		}
+	})
+
+	t.Run("key_values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		content := "# my settings\nuser = bob\n\nfile=~/chores.md\n"
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+
+		cfg, err := Load(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if v, _ := cfg.Get("user"); v != "bob" {
+			t.Errorf("user = %q, want %q", v, "bob")
+		}
+		if v, _ := cfg.Get("file"); v != "~/chores.md" {
+			t.Errorf("file = %q, want %q", v, "~/chores.md")
+		}
+	})
+
+	t.Run("unknown_key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config")
+		if err := os.WriteFile(path, []byte("colour = never\n"), 0644); err != nil {
+			t.Fatalf("failed to write config: %v", err)
+		}
+
+		_, err := Load(path)
+		if err == nil {
+			t.Fatal("expected error for unknown key")
+		}
+		if !strings.Contains(err.Error(), ":1:") {
+			t.Errorf("error should mention line number, got: %v", err)
+		}
+	})
+}
+
+func TestSetSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "config")
+	cfg, _ := Load(path)
+
+	if err := cfg.Set("color", "purple"); err == nil {
+		t.Error("expected error for invalid color value")
+	}
+	if err := cfg.Set("backups", "-1"); err == nil {
+		t.Error("expected error for a negative backup count")
+	}
+	if err := cfg.Set("color", "never"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cfg.Save(path); err != nil {
+		t.Fatalf("Save error: %v", err)
+	}
+
+	reloaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("reload error: %v", err)
+	}
+	if v, _ := reloaded.Get("color"); v != "never" {
+		t.Errorf("color = %q, want %q", v, "never")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	cfg := &Config{values: map[string]string{"color": "always"}}
+	frontMatter := map[string]string{"color": "never", "format": "tsv", "file": "other.md"}
+
+	tests := []struct {
+		name       string
+		key        string
+		flags      map[string]string
+		env        string
+		wantValue  string
+		wantSource Source
+	}{
+		{"flag_wins", "color", map[string]string{"color": "never"}, "auto", "never", SourceFlag},
+		{"env_over_config", "color", nil, "auto", "auto", SourceEnv},
+		{"config_over_front_matter", "color", nil, "", "always", SourceUser},
+		{"front_matter_over_default", "format", nil, "", "tsv", SourceFrontMatter},
+		{"file_not_from_front_matter", "file", nil, "", "chores.md", SourceDefault},
+		{"default", "user", nil, "", "", SourceDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("CHORES_COLOR", tt.env)
+
+			value, source, err := Resolve(tt.key, tt.flags, cfg, frontMatter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if value != tt.wantValue {
+				t.Errorf("value = %q, want %q", value, tt.wantValue)
+			}
+			if source != tt.wantSource {
+				t.Errorf("source = %q, want %q", source, tt.wantSource)
+			}
+		})
+	}
+
+	t.Run("unknown_key", func(t *testing.T) {
+		if _, _, err := Resolve("nope", nil, cfg, nil); err == nil {
+			t.Error("expected error for unknown key")
+		}
+	})
+}
@@ -5,30 +5,125 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// State tells whether a chore is scheduled.
+type State int
+
+const (
+	StateActive   State = iota
+	StatePaused         // Temporarily not scheduled, from "paused"
+	StateArchived       // Retired for good but kept for its history, from "archived"
+)
+
 // Chore represents a recurring household task defined in the markdown file.
 type Chore struct {
-	Name            string // The chore name from ## header
-	FrequencyDays   int    // Frequency converted to days
-	FrequencyRaw    string // Original frequency token (e.g., "2w") for display
-	DurationMinutes int    // Duration in minutes (optional)
-	DurationRaw     string // Original duration token (e.g., "1h30m") for display
-	Description     string // Optional description text after the header
-	Line            int    // Line number in file for error reporting
+	Name            string     // The chore name from ## header
+	FrequencyDays   int        // Frequency converted to days (earliest day for a range)
+	FrequencyMax    int        // Latest day for a range like "5-9d" (0 if not a range)
+	FrequencyRaw    string     // Original frequency token (e.g., "2w") for display
+	FrequencyMins   int        // Sub-daily frequency in minutes, from "12h" or "2x/d" (0 otherwise)
+	QuotaCount      int        // Completions required per calendar period, from "3x/w" (0 otherwise)
+	QuotaPeriod     string     // Calendar period of a quota: "w", "m" or "y"
+	UsageLimit      int        // Due after this much usage, from "200 cups" (0 otherwise)
+	UsageUnit       string     // Unit of the usage limit for display (e.g., "cups")
+	AfterChore      string     // Chore whose completion triggers this one, from "after:" (optional)
+	AfterWithinDays int        // Days after the trigger by which this chore is due
+	AfterWithinRaw  string     // Original "within" token (e.g., "2d") for display
+	DurationMinutes int        // Duration in minutes (optional)
+	DurationRaw     string     // Original duration token (e.g., "1h30m") for display
+	GraceDays       int        // Days past due before the chore counts as overdue (optional)
+	GraceRaw        string     // Original grace token (e.g., "2d") for display
+	MinDays         int        // Minimum days between completions, from "min 5d" (optional)
+	MinRaw          string     // Original minimum interval token for display
+	ActiveFrom      time.Month // First month of the active season (0 if always active)
+	ActiveTo        time.Month // Last month of the active season, may wrap past December
+	ActiveRaw       string     // Original season token (e.g., "apr-oct") for display
+	Once            bool       // One-off task, finished after its first completion
+	Deadline        time.Time  // Due date of a one-off task from "by" (zero if none)
+	MaxCount        int        // Finished after this many completions, from "xN" (0 if unlimited)
+	Until           time.Time  // Last day of a limited run, from "until" (zero if none)
+	State           State      // Active, paused or archived
+	Steps           []string   // Checklist items from "- [ ] step" task list lines (optional)
+	Description     string     // Optional description text after the header
+	Source          string     // File the chore is defined in ("" when parsed from a string)
+	Line            int        // Line number in file for error reporting
+	FrequencyLine   int        // Line number of the "> " line
 }
 
 // Completion represents a single completion entry (date + chore name).
 type Completion struct {
 	Date      time.Time // The date the chore was completed
 	ChoreName string    // The chore name as written in the completion entry
+	HasTime   bool      // Whether the entry included a time of day (HH:MM)
+	Person    string    // Who completed it, from a trailing @name (optional)
+	Steps     []int     // Checklist steps done, from "[steps 1,3]" (nil if fully done)
+	Archived  int       // Completions summarized by an "[archived N]" entry, the last on Date (0 otherwise)
+	Source    string    // File the entry is in ("" when parsed from a string)
+	Line      int       // Line number in file for error reporting
+}
+
+// DueDays returns the number of days after the last completion at which the
+// chore is due: the frequency, or the end of the window for a range.
+func (c Chore) DueDays() int {
+	if c.FrequencyMax > 0 {
+		return c.FrequencyMax
+	}
+	return c.FrequencyDays
+}
+
+// HasSchedule reports whether the chore has a schedule of its own, as opposed
+// to only being triggered by another chore through "after:".
+func (c Chore) HasSchedule() bool {
+	return c.FrequencyDays > 0 || c.FrequencyMins > 0 || c.QuotaCount > 0 || c.UsageLimit > 0 || c.Once
+}
+
+// ActiveIn reports whether the chore's season includes month m. Chores without
+// a season are always active.
+func (c Chore) ActiveIn(m time.Month) bool {
+	if c.ActiveFrom == 0 {
+		return true
+	}
+	if c.ActiveFrom <= c.ActiveTo {
+		return m >= c.ActiveFrom && m <= c.ActiveTo
+	}
+	return m >= c.ActiveFrom || m <= c.ActiveTo
+}
+
+// Usage represents a usage log entry: either an increment ("+3") or an
+// absolute meter reading ("= 45210") for a usage-based chore.
+type Usage struct {
+	Date      time.Time // The date of the entry
+	ChoreName string    // The chore name as written in the entry
+	Amount    int       // Increment, or meter value for a reading
+	Reading   bool      // Whether Amount is an absolute meter reading
+	Source    string    // File the entry is in ("" when parsed from a string)
 	Line      int       // Line number in file for error reporting
 }
 
+// Routine is a named group of chores done together, defined by a
+// "# Routine: Name" section listing its members.
+type Routine struct {
+	Name    string   // The routine name from the "# Routine:" header
+	Members []string // Member chore names as listed in the section
+	Source  string   // File the routine is defined in ("" when parsed from a string)
+	Line    int      // Line number in file for error reporting
+}
+
 // frequencyRegex matches frequency patterns like "1d", "2w", "1m", "3y"
 var frequencyRegex = regexp.MustCompile(`^(\d+)([dwmy])$`)
 
+// subDailyRegex matches sub-daily frequencies like "12h" or "2x/d"
+var subDailyRegex = regexp.MustCompile(`^(\d+)(h|x/d)$`)
+
+// quotaRegex matches quota frequencies like "3x/w", "2x/m"
+var quotaRegex = regexp.MustCompile(`^(\d+)x/([wmy])$`)
+
+// frequencyRangeRegex matches frequency ranges like "5-9d", "1-2w"
+var frequencyRangeRegex = regexp.MustCompile(`^(\d+)-(\d+)([dwmy])$`)
+
 // durationRegex matches duration patterns like "30m", "2h", "1h30m"
 var durationRegex = regexp.MustCompile(`^(\d+)h?(?:(\d+)m)?$|^(\d+)m$`)
 
@@ -75,6 +170,106 @@ func ParseFrequency(s string) (days int, raw string, err error) {
 	return n * multiplier, s, nil
 }
 
+// ParseFrequencyRange parses a frequency that may be a range like "5-9d" and
+// returns the minimum and maximum number of days and the original raw string.
+// A plain frequency like "2w" returns max 0.
+//
+// Returns an error if the range is empty or reversed (e.g., "9-5d", "5-5d").
+func ParseFrequencyRange(s string) (minDays, maxDays int, raw string, err error) {
+	matches := frequencyRangeRegex.FindStringSubmatch(s)
+	if matches == nil {
+		days, raw, err := ParseFrequency(s)
+		return days, 0, raw, err
+	}
+
+	unit := matches[3]
+	minDays, _, err = ParseFrequency(matches[1] + unit)
+	if err != nil {
+		return 0, 0, "", err
+	}
+	maxDays, _, err = ParseFrequency(matches[2] + unit)
+	if err != nil {
+		return 0, 0, "", err
+	}
+
+	if minDays >= maxDays {
+		return 0, 0, "", fmt.Errorf("invalid frequency range: %q (minimum must be less than maximum)", s)
+	}
+
+	return minDays, maxDays, s, nil
+}
+
+var monthNames = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March,
+	"apr": time.April, "may": time.May, "jun": time.June,
+	"jul": time.July, "aug": time.August, "sep": time.September,
+	"oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// ParseSubDaily parses a sub-daily frequency and returns the interval in minutes,
+// the original raw string, and any error encountered.
+//
+// Supported formats:
+//   - Nh: every N hours (e.g., "12h")
+//   - Nx/d: N times per day, evenly spaced (e.g., "2x/d" is every 12 hours)
+//
+// Returns an error for invalid formats or zero values.
+func ParseSubDaily(s string) (minutes int, raw string, err error) {
+	matches := subDailyRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, "", fmt.Errorf("invalid sub-daily frequency: %q (expected format like 12h, 2x/d)", s)
+	}
+
+	n, err := strconv.Atoi(matches[1])
+	if err != nil || n <= 0 {
+		return 0, "", fmt.Errorf("frequency must be positive, got: %q", s)
+	}
+
+	if matches[2] == "h" {
+		return n * 60, s, nil
+	}
+	return 24 * 60 / n, s, nil
+}
+
+// ParseQuota parses a quota frequency like "3x/w" (three times per calendar
+// week) and returns the count, the period unit ("w", "m" or "y"), the original
+// raw string, and any error encountered.
+func ParseQuota(s string) (count int, period string, raw string, err error) {
+	matches := quotaRegex.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, "", "", fmt.Errorf("invalid quota: %q (expected format like 3x/w, 2x/m)", s)
+	}
+
+	n, err := strconv.Atoi(matches[1])
+	if err != nil || n <= 0 {
+		return 0, "", "", fmt.Errorf("quota must be positive, got: %q", s)
+	}
+
+	return n, matches[2], s, nil
+}
+
+// ParseSeason parses a month or month range like "apr-oct", "nov-mar" or "dec"
+// and returns the first and last month of the season.
+//
+// Ranges may wrap past December. Month names are three-letter, case-insensitive.
+func ParseSeason(s string) (from, to time.Month, raw string, err error) {
+	first, last, isRange := strings.Cut(strings.ToLower(s), "-")
+	if !isRange {
+		last = first
+	}
+
+	from, ok := monthNames[first]
+	if !ok {
+		return 0, 0, "", fmt.Errorf("invalid season: %q (expected months like apr-oct)", s)
+	}
+	to, ok = monthNames[last]
+	if !ok {
+		return 0, 0, "", fmt.Errorf("invalid season: %q (expected months like apr-oct)", s)
+	}
+
+	return from, to, s, nil
+}
+
 // ParseDuration parses a duration string like "30m", "2h", or "1h30m" and returns the total minutes,
 // the original raw string, and any error encountered.
 //
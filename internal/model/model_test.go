@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 )
 
 func TestParseFrequency(t *testing.T) {
@@ -55,6 +56,203 @@ func TestParseFrequency(t *testing.T) {
 	}
 }
 
+func TestParseFrequencyRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantMin int
+		wantMax int
+		wantErr bool
+	}{
+		{"plain", "2w", 14, 0, false},
+		{"days", "5-9d", 5, 9, false},
+		{"weeks", "1-2w", 7, 14, false},
+		{"reversed", "9-5d", 0, 0, true},
+		{"empty_range", "5-5d", 0, 0, true},
+		{"zero_min", "0-5d", 0, 0, true},
+		{"mixed_units", "5d-2w", 0, 0, true},
+		{"invalid", "5-d", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minDays, maxDays, raw, err := ParseFrequencyRange(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseFrequencyRange(%q) expected error, got nil", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseFrequencyRange(%q) unexpected error: %v", tt.input, err)
+				return
+			}
+
+			if minDays != tt.wantMin || maxDays != tt.wantMax {
+				t.Errorf("ParseFrequencyRange(%q) = %d-%d, want %d-%d", tt.input, minDays, maxDays, tt.wantMin, tt.wantMax)
+			}
+
+			if raw != tt.input {
+				t.Errorf("ParseFrequencyRange(%q) raw = %q", tt.input, raw)
+			}
+		})
+	}
+}
+
+func TestParseSubDaily(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		wantMinutes int
+		wantErr     bool
+	}{
+		{"12h", "12h", 720, false},
+		{"1h", "1h", 60, false},
+		{"2x/d", "2x/d", 720, false},
+		{"3x/d", "3x/d", 480, false},
+		{"0h", "0h", 0, true},
+		{"0x/d", "0x/d", 0, true},
+		{"days", "2d", 0, true},
+		{"per_week", "2x/w", 0, true},
+		{"empty", "", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minutes, raw, err := ParseSubDaily(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseSubDaily(%q) expected error, got nil", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseSubDaily(%q) unexpected error: %v", tt.input, err)
+				return
+			}
+
+			if minutes != tt.wantMinutes {
+				t.Errorf("ParseSubDaily(%q) minutes = %d, want %d", tt.input, minutes, tt.wantMinutes)
+			}
+
+			if raw != tt.input {
+				t.Errorf("ParseSubDaily(%q) raw = %q", tt.input, raw)
+			}
+		})
+	}
+}
+
+func TestParseQuota(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantCount  int
+		wantPeriod string
+		wantErr    bool
+	}{
+		{"per_week", "3x/w", 3, "w", false},
+		{"per_month", "2x/m", 2, "m", false},
+		{"per_year", "4x/y", 4, "y", false},
+		{"zero", "0x/w", 0, "", true},
+		{"per_day", "2x/d", 0, "", true},
+		{"no_count", "x/w", 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, period, _, err := ParseQuota(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseQuota(%q) expected error, got nil", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseQuota(%q) unexpected error: %v", tt.input, err)
+				return
+			}
+
+			if count != tt.wantCount || period != tt.wantPeriod {
+				t.Errorf("ParseQuota(%q) = %d/%s, want %d/%s", tt.input, count, period, tt.wantCount, tt.wantPeriod)
+			}
+		})
+	}
+}
+
+func TestParseSeason(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantFrom time.Month
+		wantTo   time.Month
+		wantErr  bool
+	}{
+		{"range", "apr-oct", time.April, time.October, false},
+		{"wrapping", "nov-mar", time.November, time.March, false},
+		{"single", "dec", time.December, time.December, false},
+		{"uppercase", "Apr-Oct", time.April, time.October, false},
+		{"full_name", "april", 0, 0, true},
+		{"number", "4-10", 0, 0, true},
+		{"empty", "", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, _, err := ParseSeason(tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseSeason(%q) expected error, got nil", tt.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseSeason(%q) unexpected error: %v", tt.input, err)
+				return
+			}
+
+			if from != tt.wantFrom || to != tt.wantTo {
+				t.Errorf("ParseSeason(%q) = %v-%v, want %v-%v", tt.input, from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestActiveIn(t *testing.T) {
+	summer := Chore{ActiveFrom: time.April, ActiveTo: time.October}
+	winter := Chore{ActiveFrom: time.November, ActiveTo: time.March}
+
+	tests := []struct {
+		name  string
+		chore Chore
+		month time.Month
+		want  bool
+	}{
+		{"always", Chore{}, time.January, true},
+		{"summer_in", summer, time.April, true},
+		{"summer_end", summer, time.October, true},
+		{"summer_out", summer, time.December, false},
+		{"winter_in_dec", winter, time.December, true},
+		{"winter_in_jan", winter, time.January, true},
+		{"winter_out", winter, time.July, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.chore.ActiveIn(tt.month); got != tt.want {
+				t.Errorf("ActiveIn(%v) = %v, want %v", tt.month, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseDuration(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// cacheVersion is bumped whenever ParseResult or the parsing rules change,
+// so that results cached by an older version are not used.
+const cacheVersion = 3
+
+// racyWindow is how recently a file may have been modified for its
+// modification time not to be trusted: a later write within the same
+// timestamp tick could leave both size and time unchanged.
+const racyWindow = 2 * time.Second
+
+// cacheEntry is what ParseFileCached stores for one chores file.
+type cacheEntry struct {
+	Version  int
+	Key      string // Path of the chores file, absolute and as given
+	Files    []fileStamp
+	Listings []listing
+	Result   ParseResult
+}
+
+// fileStamp identifies the content of a file as it was parsed.
+type fileStamp struct {
+	Path    string
+	Size    int64
+	ModTime int64  // Modification time in Unix nanoseconds
+	Racy    bool   // Modified within racyWindow of being cached
+	Hash    []byte // SHA-256 of the content
+}
+
+// ParseFileCached is ParseFile with results kept in dir between runs. A
+// cached result is used as long as every file it was read from has the same
+// size and modification time, or the same content hash if only the time
+// differs, and directories and include patterns stand for the same files.
+// The cache only saves time: if it cannot be read or written the files are
+// parsed as usual. An empty dir disables it.
+func ParseFileCached(path string, dir string) (*ParseResult, error) {
+	if dir == "" {
+		return ParseFile(path)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	key := abs + "\x00" + path
+	cachePath := filepath.Join(dir, fmt.Sprintf("%x.gob", sha256.Sum256([]byte(key))))
+
+	if entry, err := readCache(cachePath); err == nil && entry.Version == cacheVersion && entry.Key == key {
+		if fresh, retouched := checkStamps(entry); fresh {
+			if retouched {
+				_ = writeCache(cachePath, entry)
+			}
+			return &entry.Result, nil
+		}
+	}
+
+	result, err := ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+	_ = writeCache(cachePath, &cacheEntry{
+		Version:  cacheVersion,
+		Key:      key,
+		Files:    result.stamps,
+		Listings: result.listings,
+		Result:   *result,
+	})
+	return result, nil
+}
+
+// checkStamps reports whether the files of a cache entry are unchanged, and
+// whether some were touched without being changed, in which case their
+// stamps are updated and the entry is worth writing again.
+func checkStamps(entry *cacheEntry) (fresh bool, retouched bool) {
+	for _, l := range entry.Listings {
+		files, err := l.list()
+		if err != nil || !slices.Equal(files, l.Matches) {
+			return false, false
+		}
+	}
+
+	for i, stamp := range entry.Files {
+		info, err := os.Stat(stamp.Path)
+		if err != nil || info.Size() != stamp.Size {
+			return false, false
+		}
+		modTime := info.ModTime()
+		if modTime.UnixNano() == stamp.ModTime && !stamp.Racy {
+			continue
+		}
+		hash, err := hashFile(stamp.Path)
+		if err != nil || !bytes.Equal(hash, stamp.Hash) {
+			return false, false
+		}
+		entry.Files[i].ModTime = modTime.UnixNano()
+		entry.Files[i].Racy = time.Since(modTime) < racyWindow
+		retouched = true
+	}
+	return true, retouched
+}
+
+func readCache(path string) (*cacheEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entry cacheEntry
+	if err := gob.NewDecoder(file).Decode(&entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// writeCache stores entry, through a temporary file so that a reader never
+// sees half of it.
+func writeCache(path string, entry *cacheEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cache-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := gob.NewEncoder(tmp).Encode(entry); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func hashFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
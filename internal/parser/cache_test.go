@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseFileCached(t *testing.T) {
+	tmpDir := t.TempDir()
+	cacheDir := filepath.Join(tmpDir, "cache")
+	path := filepath.Join(tmpDir, "chores.md")
+	old := time.Now().Add(-time.Hour)
+
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set times: %v", err)
+		}
+	}
+	completions := func() int {
+		t.Helper()
+		result, err := ParseFileCached(path, cacheDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return len(result.Completions)
+	}
+
+	write("## Trash\n> 2d\n<!-- include: log/*.md -->\n\n2026-02-01 Trash\n", old)
+	if n := completions(); n != 1 {
+		t.Fatalf("got %d completions, want 1", n)
+	}
+
+	// Same size and time: the cached result is used without reading the file.
+	write("## Trash\n> 2d\n<!-- include: log/*.md -->\n\n2026-02-02 Trash\n", old)
+	result, err := ParseFileCached(path, cacheDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Completions[0].Date.Day(); got != 1 {
+		t.Errorf("expected the cached entry for day 1, got day %d", got)
+	}
+
+	// A different time with the same size falls back to the content hash.
+	write("## Trash\n> 2d\n<!-- include: log/*.md -->\n\n2026-02-03 Trash\n", old.Add(time.Minute))
+	result, err = ParseFileCached(path, cacheDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := result.Completions[0].Date.Day(); got != 3 {
+		t.Errorf("expected the changed entry for day 3, got day %d", got)
+	}
+
+	write("## Trash\n> 2d\n<!-- include: log/*.md -->\n\n2026-02-03 Trash\n2026-02-05 Trash\n", old)
+	if n := completions(); n != 2 {
+		t.Errorf("after appending: got %d completions, want 2", n)
+	}
+
+	// A new file matching an include pattern is picked up.
+	if err := os.Mkdir(filepath.Join(tmpDir, "log"), 0755); err != nil {
+		t.Fatalf("failed to create log dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "log", "bob.md"), []byte("2026-02-06 Trash\n"), 0644); err != nil {
+		t.Fatalf("failed to write log file: %v", err)
+	}
+	if n := completions(); n != 3 {
+		t.Errorf("after adding an included file: got %d completions, want 3", n)
+	}
+
+	// Parse errors are not cached.
+	write("## Trash\n", old)
+	if _, err := ParseFileCached(path, cacheDir); err == nil {
+		t.Error("expected an error for a chore without frequency")
+	}
+}
+
+func BenchmarkParseFileCached(b *testing.B) {
+	tmpDir := b.TempDir()
+	path := filepath.Join(tmpDir, "chores.md")
+	if err := os.WriteFile(path, []byte(largeLog(100000)), 0644); err != nil {
+		b.Fatal(err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		b.Fatal(err)
+	}
+	cacheDir := filepath.Join(tmpDir, "cache")
+	if _, err := ParseFileCached(path, cacheDir); err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		if _, err := ParseFileCached(path, cacheDir); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
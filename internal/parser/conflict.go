@@ -0,0 +1,55 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsConflictCopy reports whether name is that of a copy a file sync tool
+// made of a file changed on two devices at once, such as Syncthing's
+// "chores.sync-conflict-20260203-101530-ABCDEFG.md" or Dropbox's
+// "chores (Bob's conflicted copy 2026-02-03).md".
+func IsConflictCopy(name string) bool {
+	stem := strings.TrimSuffix(name, filepath.Ext(name))
+	return strings.Contains(stem, ".sync-conflict-") || strings.Contains(stem, "conflicted copy")
+}
+
+// ConflictCopies returns the conflict copies of the file at path next to
+// it, in name order.
+func ConflictCopies(path string) ([]string, error) {
+	dir, base := filepath.Split(path)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return nil, err
+	}
+	var copies []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !IsConflictCopy(name) || filepath.Ext(name) != ext {
+			continue
+		}
+		if rest, ok := strings.CutPrefix(strings.TrimSuffix(name, ext), stem); ok && (strings.HasPrefix(rest, ".sync-conflict-") || strings.HasPrefix(rest, " (")) {
+			copies = append(copies, filepath.Join(dir, name))
+		}
+	}
+	return copies, nil
+}
+
+// globFiles returns the files matching pattern, leaving out conflict copies.
+func globFiles(pattern string) ([]string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	files := matches[:0]
+	for _, match := range matches {
+		if !IsConflictCopy(filepath.Base(match)) {
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
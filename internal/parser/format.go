@@ -0,0 +1,261 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kusha/chores-md/internal/model"
+)
+
+// leadingZerosRegex matches zeros padding a number, as in "07d" or "1h05m".
+var leadingZerosRegex = regexp.MustCompile(`(^|\D)0+(\d)`)
+
+// Format returns content in canonical form. Chore headers are followed
+// directly by their "> " line, whose tokens are rewritten in canonical form and
+// order; runs of log entries are sorted chronologically and use the chore
+// names as defined; blank lines are collapsed to one, with one before each
+// header. Descriptions, comments and fenced blocks are left as written.
+func Format(content string) (string, error) {
+	return FormatWithChores(content, nil)
+}
+
+// FormatWithChores is Format for one of several files read together, such as
+// a log included from the main file: log entries also use the names of
+// chores, given in known, that are defined in the other files.
+func FormatWithChores(content string, known []model.Chore) (string, error) {
+	result, err := Parse(content)
+	if err != nil {
+		return "", err
+	}
+
+	eol := "\n"
+	if strings.Contains(content, "\r\n") {
+		eol = "\r\n"
+	}
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	_, skip := parseFrontMatter(lines)
+
+	names := make(map[string]string)
+	for _, chore := range known {
+		names[strings.ToLower(chore.Name)] = chore.Name
+	}
+	headers := make(map[int]bool)
+	freqOf := make(map[int]int)
+	freqLines := make(map[int]bool)
+	for _, chore := range result.Chores {
+		names[strings.ToLower(chore.Name)] = chore.Name
+		headers[chore.Line-1] = true
+		if chore.FrequencyLine > 0 {
+			freqOf[chore.Line-1] = chore.FrequencyLine - 1
+			freqLines[chore.FrequencyLine-1] = true
+			if !strings.Contains(lines[chore.FrequencyLine-1], "<!--") {
+				lines[chore.FrequencyLine-1] = formatFrequencyLine(chore)
+			}
+		}
+		if !strings.Contains(lines[chore.Line-1], "<!--") {
+			lines[chore.Line-1] = "## " + chore.Name
+		}
+	}
+
+	entryDates := make(map[int]time.Time)
+	for _, c := range result.Completions {
+		entryDates[c.Line-1] = c.Date
+		lines[c.Line-1] = canonicalEntryName(lines[c.Line-1], completionRegex, 3, names)
+	}
+	for _, u := range result.Usages {
+		entryDates[u.Line-1] = u.Date
+		lines[u.Line-1] = canonicalEntryName(lines[u.Line-1], usageRegex, 2, names)
+	}
+
+	verbatim := verbatimLines(lines, skip)
+
+	// order lists the original line indexes in output order, with each run
+	// of log entries (and the blank lines between them) sorted by date.
+	var order []int
+	for i := 0; i < len(lines); i++ {
+		if _, ok := entryDates[i]; !ok || i < skip {
+			order = append(order, i)
+			continue
+		}
+		var run []int
+		end := i
+		for j := i; j < len(lines); j++ {
+			if _, ok := entryDates[j]; ok {
+				run = append(run, j)
+				end = j
+			} else if strings.TrimSpace(lines[j]) != "" || verbatim[j] {
+				break
+			}
+		}
+		sort.SliceStable(run, func(a, b int) bool { return entryDates[run[a]].Before(entryDates[run[b]]) })
+		order = append(order, run...)
+		i = end
+	}
+
+	var out []string
+	for pos, i := range order {
+		line := lines[i]
+		if i < skip {
+			out = append(out, line)
+			continue
+		}
+		// Trailing spaces are only dropped from the lines Format owns:
+		// in a description two of them are a Markdown line break.
+		_, entry := entryDates[i]
+		if !verbatim[i] && (headers[i] || freqLines[i] || entry || headingRegex.MatchString(line) || strings.TrimSpace(line) == "") {
+			line = strings.TrimRight(line, " \t")
+		}
+
+		blank := strings.TrimSpace(line) == "" && !verbatim[i]
+		if blank {
+			prevBlank := len(out) == skip || strings.TrimSpace(out[len(out)-1]) == ""
+			if prevBlank || betweenHeaderAndFrequency(order, pos, lines, headers, freqOf) {
+				continue
+			}
+		}
+		if (headers[i] || (level1Regex.MatchString(line) && !verbatim[i])) && len(out) > skip && strings.TrimSpace(out[len(out)-1]) != "" {
+			out = append(out, "")
+		}
+		out = append(out, line)
+	}
+
+	for len(out) > skip && strings.TrimSpace(out[len(out)-1]) == "" {
+		out = out[:len(out)-1]
+	}
+	if len(out) == 0 {
+		return "", nil
+	}
+	return strings.Join(out, eol) + eol, nil
+}
+
+// verbatimLines marks the lines inside (and delimiting) fenced code blocks and
+// HTML comments, which Format leaves untouched.
+func verbatimLines(lines []string, skip int) []bool {
+	verbatim := make([]bool, len(lines))
+	fence := ""
+	inComment := false
+	for i := skip; i < len(lines); i++ {
+		if fence != "" {
+			verbatim[i] = true
+			if closesFence(lines[i], fence) {
+				fence = ""
+			}
+			continue
+		}
+		wasComment := inComment
+		var code string
+		code, inComment = stripComments(lines[i], inComment)
+		if marker := fenceMarker(code); marker != "" {
+			fence = marker
+			verbatim[i] = true
+			continue
+		}
+		verbatim[i] = wasComment || inComment || code != lines[i]
+	}
+	return verbatim
+}
+
+// betweenHeaderAndFrequency reports whether the blank line at position pos of
+// order separates a chore header from its "> " line.
+func betweenHeaderAndFrequency(order []int, pos int, lines []string, headers map[int]bool, freqOf map[int]int) bool {
+	header := -1
+	for p := pos - 1; p >= 0; p-- {
+		if headers[order[p]] {
+			header = order[p]
+			break
+		}
+		if strings.TrimSpace(lines[order[p]]) != "" {
+			return false
+		}
+	}
+	freq, ok := freqOf[header]
+	if header < 0 || !ok {
+		return false
+	}
+	for p := pos + 1; p < len(order); p++ {
+		if order[p] == freq {
+			return true
+		}
+		if strings.TrimSpace(lines[order[p]]) != "" {
+			return false
+		}
+	}
+	return false
+}
+
+// canonicalEntryName replaces the chore name in a log entry, captured by
+// group of re, with the name as defined, keeping the rest of the line.
+func canonicalEntryName(line string, re *regexp.Regexp, group int, names map[string]string) string {
+	loc := re.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return line
+	}
+	start, end := loc[2*group], loc[2*group+1]
+	name, ok := names[strings.ToLower(strings.TrimSpace(line[start:end]))]
+	if !ok {
+		return line
+	}
+	return line[:start] + name + line[end:]
+}
+
+// formatFrequencyLine renders a chore's "> " line in canonical form: the
+// frequency (with its usage fallback), then duration, grace, minimum
+// interval, season, deadline or end date, count and state.
+func formatFrequencyLine(chore model.Chore) string {
+	var tokens []string
+	switch {
+	case chore.Once:
+		tokens = append(tokens, "once")
+	case chore.UsageLimit > 0:
+		tokens = append(tokens, fmt.Sprintf("%d %s", chore.UsageLimit, chore.UsageUnit))
+		if chore.FrequencyRaw != "" {
+			tokens = append(tokens, "or", trimZeros(chore.FrequencyRaw))
+		}
+	case chore.QuotaCount > 0:
+		tokens = append(tokens, fmt.Sprintf("%dx/%s", chore.QuotaCount, chore.QuotaPeriod))
+	default:
+		tokens = append(tokens, trimZeros(chore.FrequencyRaw))
+	}
+
+	if chore.DurationMinutes > 0 {
+		tokens = append(tokens, canonicalDuration(chore.DurationMinutes))
+	}
+	if chore.GraceDays > 0 {
+		tokens = append(tokens, "grace", trimZeros(chore.GraceRaw))
+	}
+	if chore.MinDays > 0 {
+		tokens = append(tokens, "min", trimZeros(chore.MinRaw))
+	}
+	if chore.ActiveFrom != 0 {
+		tokens = append(tokens, "in", strings.ToLower(chore.ActiveRaw))
+	}
+	if !chore.Deadline.IsZero() {
+		tokens = append(tokens, "by", chore.Deadline.Format("2006-01-02"))
+	}
+	if !chore.Until.IsZero() {
+		tokens = append(tokens, "until", chore.Until.Format("2006-01-02"))
+	}
+	if chore.MaxCount > 0 {
+		tokens = append(tokens, fmt.Sprintf("x%d", chore.MaxCount))
+	}
+	switch chore.State {
+	case model.StatePaused:
+		tokens = append(tokens, "paused")
+	case model.StateArchived:
+		tokens = append(tokens, "archived")
+	}
+	return "> " + strings.Join(tokens, " ")
+}
+
+// canonicalDuration formats minutes as a duration token: "45m", "2h" or
+// "1h30m".
+func canonicalDuration(minutes int) string {
+	return strings.ReplaceAll(model.FormatDuration(minutes), " ", "")
+}
+
+func trimZeros(token string) string {
+	return leadingZerosRegex.ReplaceAllString(token, "$1$2")
+}
@@ -0,0 +1,115 @@
+package parser
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name: "spacing_and_tokens",
+			content: `# Chores
+## Kitchen   
+
+> 2w   90m
+Wipe down with degreaser.
+
+
+
+## Mow Lawn
+>   01w in APR-oct paused grace 02d 45m
+2026-02-03 kitchen  # with the new sponge
+
+
+`,
+			want: `# Chores
+
+## Kitchen
+> 2w 1h30m
+Wipe down with degreaser.
+
+## Mow Lawn
+> 1w 45m grace 2d in apr-oct paused
+2026-02-03 Kitchen  # with the new sponge
+`,
+		},
+		{
+			name: "sorts_log_runs",
+			content: `## Trash
+> 2d
+
+## Coffee Machine
+> 200 cups or 3m
+
+# Completion Log
+2026-02-04 TRASH @bob
+
+2026-02-01 Trash
+2026-02-03 coffee machine +4 cups
+2026-02-03 07:30 Trash
+2026-02-03 Trash
+
+Notes in between stay put.
+
+2026-01-01 Trash
+`,
+			want: `## Trash
+> 2d
+
+## Coffee Machine
+> 200 cups or 3m
+
+# Completion Log
+2026-02-01 Trash
+2026-02-03 Coffee Machine +4 cups
+2026-02-03 Trash
+2026-02-03 07:30 Trash
+2026-02-04 Trash @bob
+
+Notes in between stay put.
+
+2026-01-01 Trash
+`,
+		},
+		{
+			name:    "keeps_verbatim_blocks",
+			content: "---\ncolor: never\n---\n\n## Filter\n> 3m\n\n```\n2026-01-02 not an entry\n\n\n## not a header\n```\n<!--\n\n\n-->\n",
+			want:    "---\ncolor: never\n---\n## Filter\n> 3m\n\n```\n2026-01-02 not an entry\n\n\n## not a header\n```\n<!--\n\n\n-->\n",
+		},
+		{
+			name:    "hard_line_break",
+			content: "## Kitchen  \n> 1w  \nWipe the counters,  \nthen the stove.\n  \n2026-02-03 Kitchen  \n",
+			want:    "## Kitchen\n> 1w\nWipe the counters,  \nthen the stove.\n\n2026-02-03 Kitchen\n",
+		},
+		{
+			name:    "crlf",
+			content: "## Trash\r\n\r\n> 2d\r\n\r\n\r\n2026-02-02 Trash\r\n2026-02-01 Trash\r\n",
+			want:    "## Trash\r\n> 2d\r\n\r\n2026-02-01 Trash\r\n2026-02-02 Trash\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Format(tt.content)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+
+			again, err := Format(got)
+			if err != nil || again != got {
+				t.Errorf("formatting should be idempotent, got:\n%s", again)
+			}
+		})
+	}
+
+	t.Run("invalid_file", func(t *testing.T) {
+		if _, err := Format("## Kitchen\nno frequency\n"); err == nil {
+			t.Error("expected error for a file that does not parse")
+		}
+	})
+}
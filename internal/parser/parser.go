@@ -3,11 +3,17 @@ package parser
 
 import (
 	"bufio"
+	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/kusha/chores-md/internal/model"
 )
@@ -15,133 +21,1125 @@ import (
 type ParseResult struct {
 	Chores      []model.Chore
 	Completions []model.Completion
+	Usages      []model.Usage
+	Routines    []model.Routine
 	Warnings    []string
+	FrontMatter map[string]string // Settings from the main file's leading --- block, if any
+	Sources     []Source          // Files read, main file first
+
+	stray    []model.Completion // Entries ignored outside the log in strict mode
+	includes []include          // Include directives, resolved by ParseFile
+	listings []listing          // Directories and include patterns read by ParseFile
+	stamps   []fileStamp        // Files read by ParseFile, for ParseFileCached
+}
+
+// Source describes one file making up a ParseResult.
+type Source struct {
+	Path string // Path of the file ("" for Parse)
+
+	// In strict mode ("strict: true" in the file's front matter), log
+	// entries are only read under a "# Completion Log" header or inside a
+	// chores-log fence.
+	Strict  bool
+	LogLine int // Last line of the last log section or fence, where entries go (0 if none)
+	Entries int // Number of log entries read from the file
+}
+
+type include struct {
+	path string
+	line int
+}
+
+// listing records the files a directory or include pattern stood for, so that
+// a cached result can tell when files are added or removed.
+type listing struct {
+	Pattern string
+	Dir     bool // Pattern is a directory of .md files
+	Matches []string
+}
+
+// list returns the files l stands for now.
+func (l listing) list() ([]string, error) {
+	if l.Dir {
+		return dirFiles(l.Pattern)
+	}
+	return globFiles(l.Pattern)
+}
+
+// Location formats a position for messages: "line 12", or
+// "log/2026.md: line 12" for a position in a file.
+func Location(source string, line int) string {
+	if source == "" {
+		return fmt.Sprintf("line %d", line)
+	}
+	return fmt.Sprintf("%s: line %d", source, line)
 }
 
 var (
 	headerRegex     = regexp.MustCompile(`^##\s+(.+)$`)
-	frequencyRegex  = regexp.MustCompile(`^>\s*(\d+[dwmy])(?:\s+(.+))?\s*$`)
-	completionRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s+(.+?)(?:\s*#.*)?$`)
+	headingRegex    = regexp.MustCompile(`^#{1,6}\s`)
+	logHeaderRegex  = regexp.MustCompile(`(?i)^#\s+Completion Log\s*$`)
+	level1Regex     = regexp.MustCompile(`^#\s+\S`)
+	includeRegex    = regexp.MustCompile(`^\s*<!--\s*include:\s*(.+?)\s*-->\s*$`)
+	routineRegex    = regexp.MustCompile(`^#\s+Routine:\s*(.+?)\s*$`)
+	memberRegex     = regexp.MustCompile(`^\s*[-*+]\s+(.+?)\s*$`)
+	frequencyRegex  = regexp.MustCompile(`^>\s*(once|\d+(?:-\d+)?[dwmy]|\d+h|\d+x/[dwmy]|\d+\s+[A-Za-z]+)(?:\s+(.+))?\s*$`)
+	afterRegex      = regexp.MustCompile(`^after:\s*(.+?)(?:\s+within\s+(\S+))?\s*$`)
+	usageRegex      = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})\s+([^#]+?)\s+([+=])\s*(\d+)(?:\s+[A-Za-z]+)?(?:\s*#.*)?$`)
+	countRegex      = regexp.MustCompile(`^x(\d+)$`)
+	taskRegex       = regexp.MustCompile(`^\s*[-*+]\s+\[[ xX]\]\s+(.+?)\s*$`)
+	completionRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})(?:\s+(\d{2}:\d{2}))?\s+(.+?)(?:\s+\[steps\s+(\d+(?:\s*,\s*\d+)*)\])?(?:\s+\[archived\s+(\d+)\])?(?:\s+@(\S+))?(?:\s*#.*)?$`)
 )
 
+// parseFrontMatter reads a leading block of "key: value" lines delimited by
+// "---" lines. It returns the settings and the number of lines consumed, or
+// (nil, 0) if the content does not start with front matter.
+func parseFrontMatter(lines []string) (map[string]string, int) {
+	if len(lines) == 0 || strings.TrimRight(lines[0], "\r") != "---" {
+		return nil, 0
+	}
+
+	settings := make(map[string]string)
+	for i := 1; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "---" {
+			return settings, i + 1
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, 0
+		}
+		settings[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return nil, 0
+}
+
+// periodUnits are words for periods of time, which are not usage units, each
+// with the frequency code to write instead ("" if there is none).
+var periodUnits = map[string]string{
+	"minute": "", "minutes": "", "min": "", "mins": "",
+	"hour": "h", "hours": "h", "hr": "h", "hrs": "h",
+	"day": "d", "days": "d",
+	"week": "w", "weeks": "w", "wk": "w", "wks": "w",
+	"month": "m", "months": "m", "mo": "m", "mos": "m",
+	"year": "y", "years": "y", "yr": "y", "yrs": "y",
+}
+
+// parseFrequency parses the first token of a "> " line into the chore's
+// schedule: "once", a usage limit ("200 cups"), a quota ("3x/w"), a sub-daily
+// frequency ("12h", "2x/d") or a frequency in days ("2w", "5-9d").
+func parseFrequency(chore *model.Chore, token string) error {
+	switch {
+	case token == "once":
+		chore.Once = true
+		chore.FrequencyRaw = token
+	case len(strings.Fields(token)) == 2:
+		fields := strings.Fields(token)
+		n, err := strconv.Atoi(fields[0])
+		if err != nil || n <= 0 {
+			return fmt.Errorf("usage limit must be positive, got: %q", token)
+		}
+		if code, ok := periodUnits[strings.ToLower(fields[1])]; ok {
+			if code == "" {
+				return fmt.Errorf("%q is a period, not a usage limit", token)
+			}
+			return fmt.Errorf("%q is a period, not a usage limit (write it as %d%s)", token, n, code)
+		}
+		chore.UsageLimit = n
+		chore.UsageUnit = fields[1]
+	case strings.Contains(token, "x/") && !strings.HasSuffix(token, "x/d"):
+		count, period, raw, err := model.ParseQuota(token)
+		if err != nil {
+			return err
+		}
+		chore.QuotaCount = count
+		chore.QuotaPeriod = period
+		chore.FrequencyRaw = raw
+	case strings.HasSuffix(token, "h") || strings.HasSuffix(token, "x/d"):
+		minutes, raw, err := model.ParseSubDaily(token)
+		if err != nil {
+			return err
+		}
+		chore.FrequencyMins = minutes
+		chore.FrequencyRaw = raw
+	default:
+		days, maxDays, raw, err := model.ParseFrequencyRange(token)
+		if err != nil {
+			return err
+		}
+		chore.FrequencyDays = days
+		chore.FrequencyMax = maxDays
+		chore.FrequencyRaw = raw
+	}
+	return nil
+}
+
+// parseClauses parses the optional tokens following the frequency on a
+// "> " line: an estimated duration, "grace <period>", "min <period>", "in <months>",
+// "by <date>", "until <date>", "xN", "or <period>" (time fallback for usage
+// limits) and the "paused"/"archived" states.
+func parseClauses(chore *model.Chore, rest string) error {
+	fields := strings.Fields(rest)
+	for i := 0; i < len(fields); i++ {
+		if matches := countRegex.FindStringSubmatch(fields[i]); matches != nil {
+			n, err := strconv.Atoi(matches[1])
+			if err != nil || n <= 0 {
+				return fmt.Errorf("invalid count: %q", fields[i])
+			}
+			chore.MaxCount = n
+			continue
+		}
+
+		switch fields[i] {
+		case "or":
+			if chore.UsageLimit == 0 {
+				return fmt.Errorf("or is only valid after a usage limit (e.g., 200 cups or 3m)")
+			}
+			if i+1 >= len(fields) {
+				return fmt.Errorf("or requires a period (e.g., or 3m)")
+			}
+			i++
+			days, raw, err := model.ParseFrequency(fields[i])
+			if err != nil {
+				return fmt.Errorf("invalid fallback period: %w", err)
+			}
+			chore.FrequencyDays = days
+			chore.FrequencyRaw = raw
+		case "paused":
+			chore.State = model.StatePaused
+		case "archived":
+			chore.State = model.StateArchived
+		case "by", "until":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("%s requires a date (e.g., %s 2026-03-01)", fields[i], fields[i])
+			}
+			date, err := time.Parse("2006-01-02", fields[i+1])
+			if err != nil {
+				return fmt.Errorf("invalid date %q after %s", fields[i+1], fields[i])
+			}
+			if fields[i] == "by" {
+				if !chore.Once {
+					return fmt.Errorf("by is only valid for once chores (use until for recurring ones)")
+				}
+				chore.Deadline = date
+			} else {
+				chore.Until = date
+			}
+			i++
+		case "grace":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("grace requires a period (e.g., grace 2d)")
+			}
+			i++
+			days, raw, err := model.ParseFrequency(fields[i])
+			if err != nil {
+				return fmt.Errorf("invalid grace period: %w", err)
+			}
+			chore.GraceDays = days
+			chore.GraceRaw = raw
+		case "min":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("min requires a period (e.g., min 5d)")
+			}
+			i++
+			days, raw, err := model.ParseFrequency(fields[i])
+			if err != nil {
+				return fmt.Errorf("invalid minimum interval: %w", err)
+			}
+			chore.MinDays = days
+			chore.MinRaw = raw
+		case "in":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("in requires a season (e.g., in apr-oct)")
+			}
+			i++
+			from, to, raw, err := model.ParseSeason(fields[i])
+			if err != nil {
+				return err
+			}
+			chore.ActiveFrom = from
+			chore.ActiveTo = to
+			chore.ActiveRaw = raw
+		default:
+			if chore.DurationMinutes > 0 {
+				return fmt.Errorf("unexpected %q after frequency", fields[i])
+			}
+			minutes, durationRaw, err := model.ParseDuration(fields[i])
+			if err != nil {
+				return err
+			}
+			chore.DurationMinutes = minutes
+			chore.DurationRaw = durationRaw
+		}
+	}
+
+	if chore.Once && (chore.MaxCount > 0 || !chore.Until.IsZero()) {
+		return fmt.Errorf("once chores cannot have a count or until date")
+	}
+	return nil
+}
+
+// stripComments removes HTML comments from a line. inComment tells whether
+// the line starts inside a comment opened on an earlier line; the returned
+// flag tells whether a comment is still open at its end.
+func stripComments(line string, inComment bool) (string, bool) {
+	var sb strings.Builder
+	for {
+		if inComment {
+			end := strings.Index(line, "-->")
+			if end < 0 {
+				return sb.String(), true
+			}
+			line = line[end+len("-->"):]
+			inComment = false
+		}
+		start := strings.Index(line, "<!--")
+		if start < 0 {
+			sb.WriteString(line)
+			return sb.String(), false
+		}
+		sb.WriteString(line[:start])
+		line = line[start+len("<!--"):]
+		inComment = true
+	}
+}
+
+// fenceMarker returns the backtick or tilde run opening a fenced code block
+// (e.g. "```" in "```sh"), or "" if the line does not open one.
+func fenceMarker(line string) string {
+	trimmed := strings.TrimSpace(line)
+	for _, c := range []string{"`", "~"} {
+		marker := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, c))]
+		if len(marker) >= 3 {
+			return marker
+		}
+	}
+	return ""
+}
+
+// closesFence reports whether line closes a fenced code block opened with
+// marker: a run of the same character at least as long, and nothing else.
+func closesFence(line, marker string) bool {
+	trimmed := strings.TrimSpace(line)
+	run := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, marker[:1]))]
+	return len(run) >= len(marker) && strings.TrimSpace(trimmed[len(run):]) == ""
+}
+
+// description joins a chore's description lines as Markdown source, without
+// leading and trailing blank lines or a trailing horizontal rule separating
+// it from the next section.
+func description(lines []string) string {
+	isBreak := func(line string) bool {
+		trimmed := strings.TrimSpace(line)
+		return trimmed == "---" || trimmed == "***" || trimmed == "___"
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && (strings.TrimSpace(lines[len(lines)-1]) == "" || isBreak(lines[len(lines)-1])) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseSteps parses the step numbers of a "[steps 1,3]" annotation, or
+// returns nil if there is none.
+func parseSteps(list string) []int {
+	if list == "" {
+		return nil
+	}
+	var steps []int
+	for _, field := range strings.Split(list, ",") {
+		n, _ := strconv.Atoi(strings.TrimSpace(field))
+		steps = append(steps, n)
+	}
+	return steps
+}
+
+// hasFrequency reports whether the chore's "> " or "after:" line has been seen.
+func hasFrequency(chore *model.Chore) bool {
+	return chore.HasSchedule() || chore.AfterChore != ""
+}
+
+// parseEntry parses a usage or completion log entry into result and reports
+// whether the line was one. Entries with an invalid date or time are skipped
+// with a warning.
+func parseEntry(result *ParseResult, source string, line string, lineNum int) bool {
+	if strings.ContainsAny(line, "+=") {
+		if matches := usageRegex.FindStringSubmatch(line); matches != nil {
+			date, err := time.Parse("2006-01-02", matches[1])
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: invalid date %q, skipping", Location(source, lineNum), matches[1]))
+				return true
+			}
+			amount, _ := strconv.Atoi(matches[4])
+
+			result.Usages = append(result.Usages, model.Usage{
+				Date:      date,
+				ChoreName: strings.TrimSpace(matches[2]),
+				Amount:    amount,
+				Reading:   matches[3] == "=",
+				Source:    source,
+				Line:      lineNum,
+			})
+			return true
+		}
+	}
+
+	if matches := matchCompletion(line); matches != nil {
+		dateStr := matches[1]
+		timeStr := matches[2]
+		choreName := strings.TrimSpace(matches[3])
+
+		date, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: invalid date %q, skipping", Location(source, lineNum), dateStr))
+			return true
+		}
+
+		date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		archived := 0
+		if matches[5] != "" {
+			archived, _ = strconv.Atoi(matches[5])
+		}
+
+		if timeStr != "" {
+			tod, err := time.Parse("15:04", timeStr)
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: invalid time %q, skipping", Location(source, lineNum), timeStr))
+				return true
+			}
+			date = date.Add(time.Duration(tod.Hour())*time.Hour + time.Duration(tod.Minute())*time.Minute)
+		}
+
+		result.Completions = append(result.Completions, model.Completion{
+			Date:      date,
+			ChoreName: choreName,
+			HasTime:   timeStr != "",
+			Person:    matches[6],
+			Steps:     parseSteps(matches[4]),
+			Archived:  archived,
+			Source:    source,
+			Line:      lineNum,
+		})
+		return true
+	}
+	return false
+}
+
+// matchCompletion matches line against completionRegex and returns the same
+// submatches, or nil. Log entries make up most of a long-lived file, and this
+// spares them the regexp engine's backtracking.
+func matchCompletion(line string) []string {
+	if len(line) < 10 || !isDate(line[:10]) {
+		return nil
+	}
+	rest := line[10:]
+	if ws := countSpaces(rest); ws > 0 && len(rest) >= ws+5 && isClock(rest[ws:ws+5]) {
+		if m := matchNamed(rest[ws+5:]); m != nil {
+			m[0], m[1], m[2] = line, line[:10], rest[ws:ws+5]
+			return m
+		}
+	}
+	if m := matchNamed(rest); m != nil {
+		m[0], m[1] = line, line[:10]
+		return m
+	}
+	return nil
+}
+
+// matchNamed matches the part of an entry from the space before the chore
+// name: the shortest name after which the annotations and comment match.
+func matchNamed(s string) []string {
+	for ws := countSpaces(s); ws >= 1; ws-- {
+		name := s[ws:]
+		for k := 0; k < len(name); {
+			r, size := utf8.DecodeRuneInString(name[k:])
+			if r == '\n' {
+				break
+			}
+			k += size
+			// Whatever follows the name starts with a space or "#".
+			if k < len(name) && !isSpace(name[k]) && name[k] != '#' {
+				continue
+			}
+			if steps, archived, person, ok := matchAnnotations(name[k:]); ok {
+				return []string{"", "", "", name[:k], steps, archived, person}
+			}
+		}
+	}
+	return nil
+}
+
+// matchAnnotations matches what follows the chore name: optional steps,
+// archived count and person, then an optional comment.
+func matchAnnotations(s string) (steps, archived, person string, ok bool) {
+	if group, rest, found := matchBracket(s, "[steps", isStepList); found {
+		if archived, person, ok := matchArchived(rest); ok {
+			return group, archived, person, true
+		}
+	}
+	archived, person, ok = matchArchived(s)
+	return "", archived, person, ok
+}
+
+func matchArchived(s string) (archived, person string, ok bool) {
+	if group, rest, found := matchBracket(s, "[archived", isDigits); found {
+		if person, ok := matchPerson(rest); ok {
+			return group, person, true
+		}
+	}
+	person, ok = matchPerson(s)
+	return "", person, ok
+}
+
+// matchPerson matches an optional "@name" and comment. The name is as long as
+// possible, but may end before a "#" that starts the comment.
+func matchPerson(s string) (string, bool) {
+	if ws := countSpaces(s); ws > 0 && ws < len(s) && s[ws] == '@' {
+		rest := s[ws+1:]
+		run := len(rest)
+		for i := 0; i < len(rest); i++ {
+			if isSpace(rest[i]) {
+				run = i
+				break
+			}
+		}
+		for l := run; l >= 1; l-- {
+			if (l == run || rest[l] == '#') && isComment(rest[l:]) {
+				return rest[:l], true
+			}
+		}
+	}
+	return "", isComment(s)
+}
+
+// matchBracket matches spaces, then "[steps" or "[archived", spaces, a group
+// accepted by valid and "]". It returns the group and what follows.
+func matchBracket(s string, open string, valid func(string) bool) (string, string, bool) {
+	ws := countSpaces(s)
+	if ws == 0 || !strings.HasPrefix(s[ws:], open) {
+		return "", "", false
+	}
+	s = s[ws+len(open):]
+	ws = countSpaces(s)
+	end := strings.IndexByte(s, ']')
+	if ws == 0 || end < ws || !valid(s[ws:end]) {
+		return "", "", false
+	}
+	return s[ws:end], s[end+1:], true
+}
+
+// isComment reports whether s is empty or a "#" comment after optional spaces.
+func isComment(s string) bool {
+	if s == "" {
+		return true
+	}
+	s = s[countSpaces(s):]
+	return strings.HasPrefix(s, "#") && !strings.Contains(s, "\n")
+}
+
+// isStepList reports whether s is a list of numbers separated by commas.
+func isStepList(s string) bool {
+	parts := strings.Split(s, ",")
+	for i, part := range parts {
+		if i > 0 {
+			part = strings.TrimLeft(part, spaces)
+		}
+		if i < len(parts)-1 {
+			part = strings.TrimRight(part, spaces)
+		}
+		if !isDigits(part) {
+			return false
+		}
+	}
+	return true
+}
+
+func isDate(s string) bool {
+	return isDigits(s[0:4]) && s[4] == '-' && isDigits(s[5:7]) && s[7] == '-' && isDigits(s[8:10])
+}
+
+func isClock(s string) bool {
+	return isDigits(s[0:2]) && s[2] == ':' && isDigits(s[3:5])
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// spaces holds the characters matched by \s.
+const spaces = " \t\n\f\r"
+
+func isSpace(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\f', '\r':
+		return true
+	}
+	return false
+}
+
+func countSpaces(s string) int {
+	n := 0
+	for n < len(s) && isSpace(s[n]) {
+		n++
+	}
+	return n
+}
+
 func Parse(content string) (*ParseResult, error) {
-	result := &ParseResult{}
-	choreMap := make(map[string]bool)
+	result, err := parseReader(strings.NewReader(content), "", int64(len(content)))
+	if err != nil {
+		return nil, err
+	}
+	finish(result)
+	return result, nil
+}
+
+// avgEntryLen is the length of a short log entry line, such as
+// "2026-02-03 Chore 5 @bob". A file that is mostly log then fills the room
+// parseReader makes for its entries without the slice growing and being
+// copied many times over.
+const avgEntryLen = 24
+
+// parseReader parses a single file line by line as it is read, leaving the
+// checks that span files to finish. Lines may be of any length. size is the
+// length of the file, used to make room for its log up front.
+func parseReader(r io.Reader, source string, size int64) (*ParseResult, error) {
+	p := &sourceParser{
+		result:     &ParseResult{Completions: make([]model.Completion, 0, size/avgEntryLen)},
+		src:        Source{Path: source},
+		choreMap:   make(map[string]bool),
+		routineMap: make(map[string]bool),
+	}
 
-	lines := strings.Split(content, "\n")
+	// A leading "---" may open front matter, which is only known once its
+	// closing line is read, so the lines up to it are held back until then.
+	var head []string
+	inHead := false
+
+	reader := bufio.NewReader(r)
+	lineNum := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line == "" && err == io.EOF {
+			break
+		}
+		lineNum++
+		line = strings.TrimSuffix(line, "\n")
+
+		if lineNum == 1 && strings.TrimRight(line, "\r") == "---" {
+			inHead = true
+		}
+		if inHead {
+			head = append(head, line)
+			if lineNum == 1 || strings.TrimSpace(line) != "---" {
+				continue
+			}
+			inHead = false
+			if err := p.frontMatter(head); err != nil {
+				return nil, err
+			}
+			head = nil
+			continue
+		}
 
-	var currentChore *model.Chore
-	var descLines []string
+		if err := p.line(line, lineNum); err != nil {
+			return nil, err
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if inHead {
+		if err := p.frontMatter(head); err != nil {
+			return nil, err
+		}
+	}
 
+	return p.end()
+}
+
+// sourceParser holds the state of parsing one file.
+type sourceParser struct {
+	result     *ParseResult
+	src        Source
+	choreMap   map[string]bool
+	routineMap map[string]bool
+
+	currentChore *model.Chore
+	descLines    []string
+	inRoutine    bool
+	inComment    bool
+	inLogSection bool
+	logFence     bool
+	fence        string
+}
+
+// frontMatter handles the lines read from a leading "---": front matter if
+// they form a valid block, otherwise ordinary lines.
+func (p *sourceParser) frontMatter(lines []string) error {
+	settings, skip := parseFrontMatter(lines)
+	if skip > 0 {
+		p.result.FrontMatter = settings
+		p.src.Strict = settings["strict"] == "true"
+		return nil
+	}
 	for i, line := range lines {
-		lineNum := i + 1
-		line = strings.TrimRight(line, "\r")
+		if err := p.line(line, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-		if matches := headerRegex.FindStringSubmatch(line); matches != nil {
-			if currentChore != nil {
-				currentChore.Description = strings.TrimSpace(strings.Join(descLines, "\n"))
-				result.Chores = append(result.Chores, *currentChore)
+// endChore completes the chore being read, if any.
+func (p *sourceParser) endChore() {
+	if p.currentChore != nil {
+		p.currentChore.Description = description(p.descLines)
+		p.result.Chores = append(p.result.Chores, *p.currentChore)
+	}
+	p.currentChore = nil
+	p.descLines = nil
+}
+
+// line parses one line of the file. Most lines are log entries, so the
+// patterns are only tried on lines starting with the character they need.
+func (p *sourceParser) line(line string, lineNum int) error {
+	result, source := p.result, p.src.Path
+	raw := strings.TrimRight(line, "\r")
+
+	// A chores-log fence holds log entries and nothing else.
+	if p.logFence {
+		if closesFence(raw, p.fence) {
+			p.fence = ""
+			p.logFence = false
+		} else if parseEntry(result, source, raw, lineNum) {
+			p.src.LogLine = lineNum
+		}
+		return nil
+	}
+
+	if p.fence == "" && !p.inComment && strings.Contains(raw, "<!--") {
+		if matches := includeRegex.FindStringSubmatch(raw); matches != nil {
+			result.includes = append(result.includes, include{path: matches[1], line: lineNum})
+			return nil
+		}
+	}
+
+	// Fenced code and HTML comments are description text, never
+	// headers or log entries.
+	if p.fence != "" {
+		if closesFence(raw, p.fence) {
+			p.fence = ""
+		}
+		line = ""
+	} else {
+		line, p.inComment = stripComments(raw, p.inComment)
+		if marker := fenceMarker(line); marker != "" {
+			p.fence = marker
+			if strings.TrimSpace(strings.TrimSpace(line)[len(marker):]) == "chores-log" {
+				p.logFence = true
+				p.src.LogLine = lineNum
+				return nil
+			}
+			line = ""
+		}
+	}
+	if strings.TrimSpace(line) == "" {
+		if p.currentChore != nil && hasFrequency(p.currentChore) {
+			p.descLines = append(p.descLines, raw)
+		}
+		return nil
+	}
+
+	heading := line[0] == '#'
+	if heading && headingRegex.MatchString(line) {
+		p.inLogSection = logHeaderRegex.MatchString(line)
+		if p.inLogSection {
+			p.src.LogLine = lineNum
+		}
+	}
+
+	if heading {
+		if matches := routineRegex.FindStringSubmatch(line); matches != nil {
+			p.endChore()
+
+			p.inRoutine = false
+			routineName := matches[1]
+			nameKey := strings.ToLower(routineName)
+			if p.routineMap[nameKey] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: duplicate routine %q (first definition wins)", Location(source, lineNum), routineName))
+				return nil
 			}
 
+			p.routineMap[nameKey] = true
+			result.Routines = append(result.Routines, model.Routine{
+				Name:   routineName,
+				Source: source,
+				Line:   lineNum,
+			})
+			p.inRoutine = true
+			return nil
+		}
+
+		if level1Regex.MatchString(line) {
+			p.endChore()
+		}
+	}
+
+	if p.inRoutine {
+		if matches := memberRegex.FindStringSubmatch(line); matches != nil {
+			routine := &result.Routines[len(result.Routines)-1]
+			routine.Members = append(routine.Members, matches[1])
+			return nil
+		}
+		if heading {
+			p.inRoutine = false
+		}
+	}
+
+	if heading {
+		if matches := headerRegex.FindStringSubmatch(line); matches != nil {
+			p.endChore()
+
 			choreName := strings.TrimSpace(matches[1])
 			nameKey := strings.ToLower(choreName)
 
-			if choreMap[nameKey] {
-				result.Warnings = append(result.Warnings, fmt.Sprintf("line %d: duplicate chore %q (first definition wins)", lineNum, choreName))
-				currentChore = nil
-				descLines = nil
-				continue
+			if p.choreMap[nameKey] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: duplicate chore %q (first definition wins)", Location(source, lineNum), choreName))
+				return nil
 			}
 
-			choreMap[nameKey] = true
-			currentChore = &model.Chore{
-				Name: choreName,
-				Line: lineNum,
+			p.choreMap[nameKey] = true
+			p.currentChore = &model.Chore{
+				Name:   choreName,
+				Source: source,
+				Line:   lineNum,
 			}
-			descLines = nil
-			continue
+			return nil
 		}
+	}
 
-		if currentChore != nil && currentChore.FrequencyDays == 0 {
-			if matches := frequencyRegex.FindStringSubmatch(line); matches != nil {
-				days, raw, err := model.ParseFrequency(matches[1])
+	// An "after:" line goes directly under the header or its "> " line;
+	// once the description has begun, it is prose like any other line.
+	chore := p.currentChore
+	if chore != nil && chore.AfterChore == "" && len(p.descLines) == 0 && strings.HasPrefix(line, "after:") {
+		if matches := afterRegex.FindStringSubmatch(line); matches != nil {
+			chore.AfterChore = strings.TrimSpace(matches[1])
+			if matches[2] != "" {
+				days, raw, err := model.ParseFrequency(matches[2])
 				if err != nil {
-					return nil, fmt.Errorf("line %d: %w", lineNum, err)
-				}
-				currentChore.FrequencyDays = days
-				currentChore.FrequencyRaw = raw
-
-				// Check if there's a duration part (group 2)
-				if len(matches) > 2 && matches[2] != "" {
-					durationStr := strings.TrimSpace(matches[2])
-					if durationStr != "" {
-						minutes, durationRaw, err := model.ParseDuration(durationStr)
-						if err != nil {
-							return nil, fmt.Errorf("line %d: %w", lineNum, err)
-						}
-						currentChore.DurationMinutes = minutes
-						currentChore.DurationRaw = durationRaw
-					}
+					return fmt.Errorf("%s: invalid within period: %w", Location(source, lineNum), err)
 				}
-				continue
+				chore.AfterWithinDays = days
+				chore.AfterWithinRaw = raw
 			}
+			return nil
 		}
+	}
 
-		if matches := completionRegex.FindStringSubmatch(line); matches != nil {
-			dateStr := matches[1]
-			choreName := strings.TrimSpace(matches[2])
-
-			date, err := time.Parse("2006-01-02", dateStr)
-			if err != nil {
-				result.Warnings = append(result.Warnings, fmt.Sprintf("line %d: invalid date %q, skipping", lineNum, dateStr))
-				continue
+	if chore != nil && chore.FrequencyLine == 0 && line[0] == '>' {
+		if matches := frequencyRegex.FindStringSubmatch(line); matches != nil {
+			chore.FrequencyLine = lineNum
+			if err := parseFrequency(chore, matches[1]); err != nil {
+				return fmt.Errorf("%s: %w", Location(source, lineNum), err)
 			}
 
-			date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
-
-			result.Completions = append(result.Completions, model.Completion{
-				Date:      date,
-				ChoreName: choreName,
-				Line:      lineNum,
-			})
-			continue
+			if err := parseClauses(chore, matches[2]); err != nil {
+				return fmt.Errorf("%s: %w", Location(source, lineNum), err)
+			}
+			return nil
 		}
+	}
 
-		if currentChore != nil && currentChore.FrequencyDays > 0 && strings.TrimSpace(line) != "" {
-			descLines = append(descLines, line)
+	if !p.src.Strict || p.inLogSection {
+		if parseEntry(result, source, line, lineNum) {
+			if p.inLogSection {
+				p.src.LogLine = lineNum
+			}
+			return nil
 		}
+	} else if matches := matchCompletion(line); matches != nil {
+		result.stray = append(result.stray, model.Completion{ChoreName: strings.TrimSpace(matches[3]), Source: source, Line: lineNum})
 	}
 
-	if currentChore != nil {
-		currentChore.Description = strings.TrimSpace(strings.Join(descLines, "\n"))
-		result.Chores = append(result.Chores, *currentChore)
+	if chore != nil && hasFrequency(chore) {
+		if matches := taskRegex.FindStringSubmatch(line); matches != nil {
+			chore.Steps = append(chore.Steps, matches[1])
+		}
+		p.descLines = append(p.descLines, raw)
 	}
+	return nil
+}
+
+// end completes the file once all lines are read.
+func (p *sourceParser) end() (*ParseResult, error) {
+	p.endChore()
+	result := p.result
 
 	for i := range result.Chores {
-		if result.Chores[i].FrequencyDays == 0 {
-			return nil, fmt.Errorf("line %d: chore %q has no frequency defined", result.Chores[i].Line, result.Chores[i].Name)
+		if !hasFrequency(&result.Chores[i]) {
+			return nil, fmt.Errorf("%s: chore %q has no frequency defined", Location(p.src.Path, result.Chores[i].Line), result.Chores[i].Name)
 		}
 	}
 
+	p.src.Entries = len(result.Completions) + len(result.Usages)
+	result.Sources = []Source{p.src}
+
 	return result, nil
 }
 
+// finish runs the checks that need every file of a result: references to
+// unknown chores, entries outside the log in strict mode, and step numbers.
+func finish(result *ParseResult) {
+	ResolvePersons(result.Completions, result.Chores)
+
+	choreMap := make(map[string]bool)
+	for _, chore := range result.Chores {
+		choreMap[strings.ToLower(chore.Name)] = true
+	}
+
+	for _, chore := range result.Chores {
+		if chore.AfterChore != "" && !choreMap[strings.ToLower(chore.AfterChore)] {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: chore %q follows unknown chore %q", Location(chore.Source, chore.Line), chore.Name, chore.AfterChore))
+		}
+	}
+
+	for _, c := range result.stray {
+		if choreMap[strings.ToLower(c.ChoreName)] {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: entry for %q is outside the completion log and was ignored", Location(c.Source, c.Line), c.ChoreName))
+		}
+	}
+
+	stepCounts := make(map[string]int)
+	for _, chore := range result.Chores {
+		stepCounts[strings.ToLower(chore.Name)] = len(chore.Steps)
+	}
+	for _, c := range result.Completions {
+		count, known := stepCounts[strings.ToLower(c.ChoreName)]
+		if !known {
+			continue
+		}
+		for _, step := range c.Steps {
+			if step < 1 || step > count {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %q has no step %d", Location(c.Source, c.Line), c.ChoreName, step))
+			}
+		}
+	}
+
+	for _, routine := range result.Routines {
+		if len(routine.Members) == 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: routine %q has no chores", Location(routine.Source, routine.Line), routine.Name))
+		}
+		for _, member := range routine.Members {
+			if !choreMap[strings.ToLower(member)] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: routine %q lists unknown chore %q", Location(routine.Source, routine.Line), routine.Name, member))
+			}
+		}
+	}
+}
+
+// ResolvePersons reads an entry such as "2026-01-01 Email @work" as one for
+// the chore "Email @work", rather than "Email" done by "work", when a chore
+// of that name is defined: it is what done writes for that chore.
+func ResolvePersons(completions []model.Completion, chores []model.Chore) {
+	names := make(map[string]bool)
+	for _, chore := range chores {
+		names[strings.ToLower(chore.Name)] = true
+	}
+	for i, c := range completions {
+		if c.Person == "" {
+			continue
+		}
+		if name := c.ChoreName + " @" + c.Person; names[strings.ToLower(name)] {
+			completions[i].ChoreName = name
+			completions[i].Person = ""
+		}
+	}
+}
+
+// ParseFile parses a chores file together with the files it includes
+// through "<!-- include: path -->" directives (paths are relative to the
+// including file and may be globs). If path is a directory, all of its .md
+// files are read, chores.md first. Each file is read once.
 func ParseFile(path string) (*ParseResult, error) {
-	file, err := os.Open(path)
+	info, err := os.Stat(path)
 	if err != nil {
 		return nil, err
 	}
-	defer file.Close()
 
-	var sb strings.Builder
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		sb.WriteString(scanner.Text())
-		sb.WriteString("\n")
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = dirFiles(path); err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no .md files in %s", path)
+		}
+	}
+
+	result := &ParseResult{}
+	if info.IsDir() {
+		result.listings = append(result.listings, listing{Pattern: path, Dir: true, Matches: files})
+	}
+	loaded := make(map[string]bool)
+	for _, file := range files {
+		if err := load(result, file, loaded); err != nil {
+			return nil, err
+		}
+	}
+	finish(result)
+	return result, nil
+}
+
+// Changed reports whether content differs from the file at path as it was
+// read by ParseFile, which tells commands that rewrite the file whether it
+// was changed by someone else in the meantime. Files not read report false.
+func (r *ParseResult) Changed(path string, content []byte) bool {
+	for _, stamp := range r.stamps {
+		if stamp.Path == path {
+			sum := sha256.Sum256(content)
+			return !bytes.Equal(sum[:], stamp.Hash)
+		}
 	}
-	if err := scanner.Err(); err != nil {
+	return false
+}
+
+// dirFiles lists the .md files in dir in name order, with chores.md first.
+// Conflict copies are left out.
+func dirFiles(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
 		return nil, err
 	}
 
-	return Parse(sb.String())
+	var files []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" || IsConflictCopy(entry.Name()) {
+			continue
+		}
+		file := filepath.Join(dir, entry.Name())
+		if entry.Name() == "chores.md" {
+			files = append([]string{file}, files...)
+		} else {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// load parses one file and the files it includes into result. Files already
+// in loaded are skipped, which also breaks include cycles.
+func load(result *ParseResult, path string, loaded map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if loaded[abs] {
+		return nil
+	}
+	loaded[abs] = true
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	hash := sha256.New()
+	r, err := parseReader(io.TeeReader(file, hash), path, info.Size())
+	file.Close()
+	if err != nil {
+		return err
+	}
+	merge(result, r)
+	result.stamps = append(result.stamps, fileStamp{
+		Path:    path,
+		Size:    info.Size(),
+		ModTime: info.ModTime().UnixNano(),
+		Racy:    time.Since(info.ModTime()) < racyWindow,
+		Hash:    hash.Sum(nil),
+	})
+
+	for _, inc := range r.includes {
+		pattern := inc.path
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(filepath.Dir(path), pattern)
+		}
+		matches, err := globFiles(pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid include %q: %w", Location(path, inc.line), inc.path, err)
+		}
+		if !strings.ContainsAny(inc.path, "*?[") {
+			if len(matches) == 0 {
+				return fmt.Errorf("%s: included file %q not found", Location(path, inc.line), inc.path)
+			}
+		} else {
+			result.listings = append(result.listings, listing{Pattern: pattern, Matches: matches})
+		}
+		for _, match := range matches {
+			if err := load(result, match, loaded); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// merge adds the result of parsing one more file to result. Chores and
+// routines already defined in an earlier file are dropped with a warning.
+func merge(result *ParseResult, r *ParseResult) {
+	if len(result.Sources) == 0 {
+		result.FrontMatter = r.FrontMatter
+	}
+
+	chores := make(map[string]model.Chore)
+	for _, chore := range result.Chores {
+		chores[strings.ToLower(chore.Name)] = chore
+	}
+	for _, chore := range r.Chores {
+		if first, ok := chores[strings.ToLower(chore.Name)]; ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: duplicate chore %q (first definition in %s wins)", Location(chore.Source, chore.Line), chore.Name, first.Source))
+			continue
+		}
+		result.Chores = append(result.Chores, chore)
+	}
+
+	routines := make(map[string]model.Routine)
+	for _, routine := range result.Routines {
+		routines[strings.ToLower(routine.Name)] = routine
+	}
+	for _, routine := range r.Routines {
+		if first, ok := routines[strings.ToLower(routine.Name)]; ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: duplicate routine %q (first definition in %s wins)", Location(routine.Source, routine.Line), routine.Name, first.Source))
+			continue
+		}
+		result.Routines = append(result.Routines, routine)
+	}
+
+	if result.Completions == nil {
+		result.Completions = r.Completions
+	} else {
+		result.Completions = append(result.Completions, r.Completions...)
+	}
+	result.Usages = append(result.Usages, r.Usages...)
+	result.Warnings = append(result.Warnings, r.Warnings...)
+	result.Sources = append(result.Sources, r.Sources...)
+	result.stray = append(result.stray, r.stray...)
 }
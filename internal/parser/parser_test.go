@@ -1,8 +1,14 @@
 package parser
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/kusha/chores-md/internal/model"
 )
 
 func TestParse(t *testing.T) {
@@ -111,6 +117,91 @@ Some description but no frequency.
 		}
 	})
 
+	t.Run("completion_with_person", func(t *testing.T) {
+		content := `## Kitchen
+> 1w
+
+2026-02-03 Kitchen @bob # after dinner
+2026-02-04 Kitchen
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Completions) != 2 {
+			t.Fatalf("got %d completions, want 2", len(result.Completions))
+		}
+		if result.Completions[0].ChoreName != "Kitchen" {
+			t.Errorf("completion name = %q, want %q", result.Completions[0].ChoreName, "Kitchen")
+		}
+		if result.Completions[0].Person != "bob" {
+			t.Errorf("person = %q, want %q", result.Completions[0].Person, "bob")
+		}
+		if result.Completions[1].Person != "" {
+			t.Errorf("person = %q, want empty", result.Completions[1].Person)
+		}
+	})
+
+	t.Run("chore_name_with_at", func(t *testing.T) {
+		content := `## Email @work
+> 1d
+
+## Email
+> 1w
+
+2026-02-03 Email @work
+2026-02-04 Email @work @bob
+2026-02-05 Email @bob
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := [][2]string{{"Email @work", ""}, {"Email @work", "bob"}, {"Email", "bob"}}
+		for i, c := range result.Completions {
+			if got := [2]string{c.ChoreName, c.Person}; got != want[i] {
+				t.Errorf("completion %d = %q by %q, want %q by %q", i, got[0], got[1], want[i][0], want[i][1])
+			}
+		}
+	})
+
+	t.Run("front_matter", func(t *testing.T) {
+		content := `---
+color: never
+format: tsv
+---
+## Kitchen
+> 1w
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.FrontMatter["color"] != "never" || result.FrontMatter["format"] != "tsv" {
+			t.Errorf("front matter = %v, want color and format", result.FrontMatter)
+		}
+		if len(result.Chores) != 1 || result.Chores[0].Line != 5 {
+			t.Errorf("chore should be parsed after front matter at line 5, got %+v", result.Chores)
+		}
+	})
+
+	t.Run("horizontal_rule_not_front_matter", func(t *testing.T) {
+		content := `## Kitchen
+> 1w
+
+---
+
+2026-02-03 Kitchen
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.FrontMatter != nil {
+			t.Errorf("front matter = %v, want nil", result.FrontMatter)
+		}
+	})
+
 	t.Run("duplicate_chore", func(t *testing.T) {
 		content := `## Kitchen
 > 1w
@@ -179,6 +270,346 @@ Some description but no frequency.
 		}
 	})
 
+	t.Run("frequency_range", func(t *testing.T) {
+		content := `## Water Plants
+> 5-9d 10m
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		chore := result.Chores[0]
+		if chore.FrequencyDays != 5 || chore.FrequencyMax != 9 {
+			t.Errorf("frequency = %d-%d, want 5-9", chore.FrequencyDays, chore.FrequencyMax)
+		}
+		if chore.FrequencyRaw != "5-9d" {
+			t.Errorf("FrequencyRaw = %q, want %q", chore.FrequencyRaw, "5-9d")
+		}
+		if chore.DurationMinutes != 10 {
+			t.Errorf("duration = %d, want 10", chore.DurationMinutes)
+		}
+
+		if _, err := Parse("## Water Plants\n> 9-5d\n"); err == nil {
+			t.Error("expected error for reversed range")
+		}
+	})
+
+	t.Run("grace_clause", func(t *testing.T) {
+		tests := []struct {
+			content      string
+			wantGrace    int
+			wantDuration int
+		}{
+			{"## Kitchen\n> 1w grace 2d\n", 2, 0},
+			{"## Kitchen\n> 1w 30m grace 1w\n", 7, 30},
+			{"## Kitchen\n> 1w grace 3d 1h\n", 3, 60},
+			{"## Kitchen\n> 1w in apr-oct grace 3d\n", 3, 0},
+			{"## Kitchen\n> 1w min 5d grace 1d 10m\n", 1, 10},
+		}
+		for _, tt := range tests {
+			result, err := Parse(tt.content)
+			if err != nil {
+				t.Errorf("Parse(%q) error: %v", tt.content, err)
+				continue
+			}
+			if result.Chores[0].GraceDays != tt.wantGrace {
+				t.Errorf("Parse(%q) grace = %d, want %d", tt.content, result.Chores[0].GraceDays, tt.wantGrace)
+			}
+			if result.Chores[0].DurationMinutes != tt.wantDuration {
+				t.Errorf("Parse(%q) duration = %d, want %d", tt.content, result.Chores[0].DurationMinutes, tt.wantDuration)
+			}
+		}
+	})
+
+	t.Run("season_clause", func(t *testing.T) {
+		result, err := Parse("## Mow Lawn\n> 1w 45m in apr-oct\n")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		chore := result.Chores[0]
+		if chore.ActiveFrom != time.April || chore.ActiveTo != time.October {
+			t.Errorf("season = %v-%v, want April-October", chore.ActiveFrom, chore.ActiveTo)
+		}
+		if chore.ActiveRaw != "apr-oct" {
+			t.Errorf("ActiveRaw = %q, want %q", chore.ActiveRaw, "apr-oct")
+		}
+	})
+
+	t.Run("limited_run_clauses", func(t *testing.T) {
+		content := `## Replace Caulk
+> once by 2026-03-01 2h
+
+## Medication
+> 1d x10
+
+## Water Seedlings
+> 1w until 2026-06-01
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Chores) != 3 {
+			t.Fatalf("got %d chores, want 3", len(result.Chores))
+		}
+
+		caulk := result.Chores[0]
+		if !caulk.Once || !caulk.Deadline.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("once chore = %+v, want once by 2026-03-01", caulk)
+		}
+		if caulk.DurationMinutes != 120 {
+			t.Errorf("once chore duration = %d, want 120", caulk.DurationMinutes)
+		}
+		if result.Chores[1].MaxCount != 10 {
+			t.Errorf("MaxCount = %d, want 10", result.Chores[1].MaxCount)
+		}
+		if !result.Chores[2].Until.Equal(time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("Until = %v, want 2026-06-01", result.Chores[2].Until)
+		}
+	})
+
+	t.Run("state_clause", func(t *testing.T) {
+		content := `## Wash Car
+> 2w paused
+
+## Mow Lawn
+
+> 1w archived 1h
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Chores[0].State != model.StatePaused {
+			t.Errorf("state = %v, want StatePaused", result.Chores[0].State)
+		}
+		if result.Chores[1].State != model.StateArchived {
+			t.Errorf("state = %v, want StateArchived", result.Chores[1].State)
+		}
+		if result.Chores[1].FrequencyLine != 6 {
+			t.Errorf("FrequencyLine = %d, want 6", result.Chores[1].FrequencyLine)
+		}
+	})
+
+	t.Run("sub_daily", func(t *testing.T) {
+		content := `## Feed Cat
+> 2x/d 5m
+
+## Medication
+> 8h
+
+2026-02-10 07:45 Feed Cat @bob
+2026-02-09 Feed Cat
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if result.Chores[0].FrequencyMins != 720 || result.Chores[0].DurationMinutes != 5 {
+			t.Errorf("Feed Cat = %d min every %d min, want 5 every 720", result.Chores[0].DurationMinutes, result.Chores[0].FrequencyMins)
+		}
+		if result.Chores[1].FrequencyMins != 480 {
+			t.Errorf("Medication FrequencyMins = %d, want 480", result.Chores[1].FrequencyMins)
+		}
+
+		timed := result.Completions[0]
+		if !timed.HasTime || !timed.Date.Equal(time.Date(2026, 2, 10, 7, 45, 0, 0, time.UTC)) {
+			t.Errorf("timed completion = %v (HasTime %v), want 2026-02-10 07:45", timed.Date, timed.HasTime)
+		}
+		if timed.ChoreName != "Feed Cat" || timed.Person != "bob" {
+			t.Errorf("timed completion name/person = %q/%q", timed.ChoreName, timed.Person)
+		}
+		if result.Completions[1].HasTime {
+			t.Error("date-only completion should not have a time")
+		}
+	})
+
+	t.Run("quota", func(t *testing.T) {
+		result, err := Parse("## Vacuum\n> 3x/w 20m\n")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		chore := result.Chores[0]
+		if chore.QuotaCount != 3 || chore.QuotaPeriod != "w" {
+			t.Errorf("quota = %d/%s, want 3/w", chore.QuotaCount, chore.QuotaPeriod)
+		}
+		if chore.FrequencyMins != 0 || chore.FrequencyDays != 0 {
+			t.Errorf("quota chore should have no interval, got %d days %d mins", chore.FrequencyDays, chore.FrequencyMins)
+		}
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		content := `## Descale Coffee Machine
+> 200 cups or 3m 15m
+
+## Rotate Tires
+> 8000 km
+
+2026-02-01 Descale Coffee Machine
+2026-02-02 Descale Coffee Machine +3 cups
+2026-02-03 Descale Coffee Machine +2
+2026-02-03 Rotate Tires = 45210 km # odometer
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		coffee := result.Chores[0]
+		if coffee.UsageLimit != 200 || coffee.UsageUnit != "cups" {
+			t.Errorf("usage limit = %d %s, want 200 cups", coffee.UsageLimit, coffee.UsageUnit)
+		}
+		if coffee.FrequencyDays != 90 || coffee.DurationMinutes != 15 {
+			t.Errorf("fallback/duration = %d days/%d min, want 90/15", coffee.FrequencyDays, coffee.DurationMinutes)
+		}
+		if result.Chores[1].UsageLimit != 8000 || result.Chores[1].FrequencyDays != 0 {
+			t.Errorf("tires = %+v, want 8000 km without fallback", result.Chores[1])
+		}
+
+		if len(result.Completions) != 1 {
+			t.Errorf("got %d completions, want 1 (usage entries are not completions)", len(result.Completions))
+		}
+		if len(result.Usages) != 3 {
+			t.Fatalf("got %d usages, want 3", len(result.Usages))
+		}
+		if result.Usages[0].Amount != 3 || result.Usages[0].Reading {
+			t.Errorf("first usage = %+v, want +3", result.Usages[0])
+		}
+		reading := result.Usages[2]
+		if !reading.Reading || reading.Amount != 45210 || reading.ChoreName != "Rotate Tires" {
+			t.Errorf("reading = %+v, want Rotate Tires = 45210", reading)
+		}
+
+		if _, err := Parse("## Kitchen\n> 1w or 2w\n"); err == nil {
+			t.Error("expected error for or without usage limit")
+		}
+
+		for _, line := range []string{"> 3 months", "> 2 Weeks", "> 90 minutes"} {
+			if _, err := Parse("## Kitchen\n" + line + "\n"); err == nil || !strings.Contains(err.Error(), "is a period") {
+				t.Errorf("%s: expected a period error, got: %v", line, err)
+			}
+		}
+	})
+
+	t.Run("usage_sign_in_comment", func(t *testing.T) {
+		content := "## Feed Cat\n> 12h\n\n2026-02-03 08:00 Feed cat # +1 can\n2026-02-03 Feed cat # fed = 2 cans\n"
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Usages) != 0 || len(result.Completions) != 2 {
+			t.Errorf("got %d usages and %d completions, want 0 and 2", len(result.Usages), len(result.Completions))
+		}
+		for _, c := range result.Completions {
+			if c.ChoreName != "Feed cat" {
+				t.Errorf("completion name = %q, want Feed cat", c.ChoreName)
+			}
+		}
+	})
+
+	t.Run("after", func(t *testing.T) {
+		content := `## Mow Lawn
+> 1w
+
+## Clean Mower Deck
+after: Mow Lawn within 2d
+
+Scrape off the grass.
+
+## Write Down Filter Size
+after: Change Furnace Filter
+> 1y
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		deck := result.Chores[1]
+		if deck.AfterChore != "Mow Lawn" || deck.AfterWithinDays != 2 {
+			t.Errorf("after = %q within %d, want Mow Lawn within 2", deck.AfterChore, deck.AfterWithinDays)
+		}
+		if deck.HasSchedule() {
+			t.Error("follow-up chore should have no schedule of its own")
+		}
+		if deck.Description != "Scrape off the grass." {
+			t.Errorf("description = %q", deck.Description)
+		}
+
+		filter := result.Chores[2]
+		if filter.AfterChore != "Change Furnace Filter" || filter.FrequencyDays != 365 {
+			t.Errorf("filter chore = %+v, want after and 1y", filter)
+		}
+		if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "unknown chore") {
+			t.Errorf("expected unknown chore warning, got %v", result.Warnings)
+		}
+	})
+
+	t.Run("after_in_description", func(t *testing.T) {
+		content := `## Vacuuming
+> 1w
+
+## Mop
+> 1w
+Use the blue bucket.
+after: vacuuming, mop within reason
+
+## Dust
+> 2w
+
+after: vacuuming, mop the floor
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, chore := range result.Chores[1:] {
+			if chore.AfterChore != "" {
+				t.Errorf("%s: after = %q, want none", chore.Name, chore.AfterChore)
+			}
+		}
+		if want := "Use the blue bucket.\nafter: vacuuming, mop within reason"; result.Chores[1].Description != want {
+			t.Errorf("Mop description = %q, want %q", result.Chores[1].Description, want)
+		}
+		if want := "after: vacuuming, mop the floor"; result.Chores[2].Description != want {
+			t.Errorf("Dust description = %q, want %q", result.Chores[2].Description, want)
+		}
+		if len(result.Warnings) != 0 {
+			t.Errorf("unexpected warnings: %v", result.Warnings)
+		}
+	})
+
+	t.Run("invalid_time", func(t *testing.T) {
+		result, err := Parse("## Feed Cat\n> 12h\n\n2026-02-10 25:00 Feed Cat\n")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Completions) != 0 || len(result.Warnings) != 1 {
+			t.Errorf("got %d completions and %d warnings, want 0 and 1", len(result.Completions), len(result.Warnings))
+		}
+	})
+
+	t.Run("invalid_clauses", func(t *testing.T) {
+		tests := []string{
+			"## Kitchen\n> 1w grace\n",
+			"## Kitchen\n> 1w grace soon\n",
+			"## Kitchen\n> 1w 30m 1h\n",
+			"## Kitchen\n> 1w in\n",
+			"## Kitchen\n> 1w in spring\n",
+			"## Kitchen\n> 1w by 2026-03-01\n",
+			"## Kitchen\n> once until 2026-03-01\n",
+			"## Kitchen\n> 1w until tomorrow\n",
+			"## Kitchen\n> 1d x0\n",
+			"## Kitchen\n> 1w min\n",
+		}
+		for _, content := range tests {
+			if _, err := Parse(content); err == nil {
+				t.Errorf("Parse(%q) expected error", content)
+			} else if !strings.Contains(err.Error(), "line 2") {
+				t.Errorf("Parse(%q) error should mention line 2, got: %v", content, err)
+			}
+		}
+	})
+
 	t.Run("completion_before_definition", func(t *testing.T) {
 		content := `# Log
 2026-02-03 Kitchen
@@ -198,6 +629,185 @@ Some description but no frequency.
 			t.Errorf("got %d chores, want 1", len(result.Chores))
 		}
 	})
+
+	t.Run("routine_section", func(t *testing.T) {
+		content := `## Vacuum
+> 1w 45m
+
+Move the couch.
+
+# Routine: Saturday
+- Vacuum
+* Mop Floors
+- Dust Shelves
+
+## Mop Floors
+> 1w 30m
+
+# Completion Log
+2026-02-07 Vacuum
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Routines) != 1 {
+			t.Fatalf("got %d routines, want 1", len(result.Routines))
+		}
+		routine := result.Routines[0]
+		if routine.Name != "Saturday" || routine.Line != 6 {
+			t.Errorf("routine = %q at line %d, want %q at line 6", routine.Name, routine.Line, "Saturday")
+		}
+		want := []string{"Vacuum", "Mop Floors", "Dust Shelves"}
+		if strings.Join(routine.Members, "|") != strings.Join(want, "|") {
+			t.Errorf("members = %q, want %q", routine.Members, want)
+		}
+		if result.Chores[0].Description != "Move the couch." {
+			t.Errorf("routine should end the previous chore's description, got %q", result.Chores[0].Description)
+		}
+		if len(result.Chores) != 2 || len(result.Completions) != 1 {
+			t.Errorf("got %d chores and %d completions, want 2 and 1", len(result.Chores), len(result.Completions))
+		}
+		if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], `unknown chore "Dust Shelves"`) {
+			t.Errorf("expected a warning for the unknown member, got %v", result.Warnings)
+		}
+	})
+
+	t.Run("checklist_steps", func(t *testing.T) {
+		content := `## Bathroom
+> 1w
+
+Weekly clean:
+- [ ] Scrub toilet
+- [x] Clean mirror
+* [ ] Mop floor
+
+2026-02-09 Bathroom [steps 1, 3] @bob # mirror later
+2026-02-10 Bathroom [steps 4]
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"Scrub toilet", "Clean mirror", "Mop floor"}
+		if strings.Join(result.Chores[0].Steps, "|") != strings.Join(want, "|") {
+			t.Errorf("steps = %q, want %q", result.Chores[0].Steps, want)
+		}
+		c := result.Completions[0]
+		if c.ChoreName != "Bathroom" || c.Person != "bob" || len(c.Steps) != 2 || c.Steps[0] != 1 || c.Steps[1] != 3 {
+			t.Errorf("completion = %+v, want Bathroom steps [1 3] by bob", c)
+		}
+		if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "no step 4") {
+			t.Errorf("expected a warning for the unknown step, got %v", result.Warnings)
+		}
+	})
+
+	t.Run("archive_summary", func(t *testing.T) {
+		content := `## Trash
+> 1w
+
+2024-12-30 18:00 Trash [archived 52] @bob
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		c := result.Completions[0]
+		if c.ChoreName != "Trash" || c.Archived != 52 || !c.HasTime || c.Person != "bob" {
+			t.Errorf("completion = %+v, want Trash archived 52 at 18:00 by bob", c)
+		}
+	})
+
+	t.Run("markdown_description", func(t *testing.T) {
+		content := "## Furnace Filter\n" +
+			"> 3m\n" +
+			"\n" +
+			"Size is on the [manual](https://example.com/manual).\n" +
+			"\n" +
+			"- Turn off the furnace\n" +
+			"- Swap the filter\n" +
+			"\n" +
+			"```\n" +
+			"## Not A Chore\n" +
+			"2026-01-10 bought new filter, model X\n" +
+			"```\n" +
+			"<!--\n" +
+			"2026-01-11 old note\n" +
+			"-->\n" +
+			"\n" +
+			"---\n" +
+			"\n" +
+			"# Completion Log\n" +
+			"\n" +
+			"2026-01-12 Furnace Filter <!-- finally -->\n"
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Chores) != 1 {
+			t.Fatalf("got %d chores, want 1", len(result.Chores))
+		}
+		want := "Size is on the [manual](https://example.com/manual).\n\n" +
+			"- Turn off the furnace\n- Swap the filter\n\n" +
+			"```\n## Not A Chore\n2026-01-10 bought new filter, model X\n```\n" +
+			"<!--\n2026-01-11 old note\n-->"
+		if result.Chores[0].Description != want {
+			t.Errorf("description = %q, want %q", result.Chores[0].Description, want)
+		}
+		if len(result.Completions) != 1 || result.Completions[0].ChoreName != "Furnace Filter" {
+			t.Errorf("only the log entry should be a completion, got %+v", result.Completions)
+		}
+	})
+
+	t.Run("strict_log", func(t *testing.T) {
+		content := `---
+strict: true
+---
+## Water Filter
+> 3m
+
+2026-01-10 bought new filter, model X
+2026-01-10 Water Filter
+
+# Completion Log
+
+2026-01-11 Water Filter
+2026-01-12 Water Filter +3 l
+
+# Notes
+2026-01-13 Water Filter
+
+` + "```chores-log" + `
+2026-01-14 Water Filter
+` + "```" + `
+`
+		result, err := Parse(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Sources[0].Strict {
+			t.Fatal("front matter should enable strict mode")
+		}
+		var lines []int
+		for _, c := range result.Completions {
+			lines = append(lines, c.Line)
+		}
+		if len(lines) != 2 || lines[0] != 12 || lines[1] != 19 {
+			t.Errorf("completion lines = %v, want [12 19]", lines)
+		}
+		if len(result.Usages) != 1 {
+			t.Errorf("got %d usages, want 1", len(result.Usages))
+		}
+		if result.Sources[0].LogLine != 19 {
+			t.Errorf("log line = %d, want 19", result.Sources[0].LogLine)
+		}
+		if !strings.Contains(result.Chores[0].Description, "bought new filter") {
+			t.Errorf("dated note should stay in the description, got %q", result.Chores[0].Description)
+		}
+		if len(result.Warnings) != 2 || !strings.Contains(result.Warnings[0], "line 8:") || !strings.Contains(result.Warnings[1], "line 16:") {
+			t.Errorf("expected warnings for entries outside the log, got %v", result.Warnings)
+		}
+	})
 }
 
 func TestParseFile(t *testing.T) {
@@ -259,4 +869,153 @@ func TestParseFile(t *testing.T) {
 			t.Fatal("expected error for nonexistent file")
 		}
 	})
+
+	t.Run("includes", func(t *testing.T) {
+		for _, path := range []string{"testdata/split/chores.md", "testdata/split"} {
+			result, err := ParseFile(path)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", path, err)
+			}
+			if len(result.Chores) != 3 || len(result.Completions) != 4 {
+				t.Errorf("%s: got %d chores and %d completions, want 3 and 4", path, len(result.Chores), len(result.Completions))
+			}
+			var sources []string
+			for _, src := range result.Sources {
+				sources = append(sources, src.Path)
+			}
+			want := "testdata/split/chores.md testdata/split/log/2026.md testdata/split/log/bob.md testdata/split/extra.md"
+			if strings.Join(sources, " ") != want {
+				t.Errorf("%s: sources = %v, want %s", path, sources, want)
+			}
+			if result.Completions[0].Source != "testdata/split/log/2026.md" {
+				t.Errorf("%s: completion source = %q", path, result.Completions[0].Source)
+			}
+			warnings := strings.Join(result.Warnings, "\n")
+			if !strings.Contains(warnings, "testdata/split/log/2026.md: line 3: invalid date") {
+				t.Errorf("%s: warnings should name the file and line, got:\n%s", path, warnings)
+			}
+			if !strings.Contains(warnings, `testdata/split/extra.md: line 1: duplicate chore "Take Out Trash" (first definition in testdata/split/chores.md wins)`) {
+				t.Errorf("%s: expected duplicate warning across files, got:\n%s", path, warnings)
+			}
+		}
+	})
+
+	t.Run("missing_include", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "chores.md")
+		if err := os.WriteFile(path, []byte("## Trash\n> 2d\n<!-- include: log.md -->\n"), 0644); err != nil {
+			t.Fatalf("failed to write test file: %v", err)
+		}
+		_, err := ParseFile(path)
+		if err == nil || !strings.Contains(err.Error(), "line 3: included file \"log.md\" not found") {
+			t.Errorf("expected missing include error, got: %v", err)
+		}
+	})
+
+	t.Run("conflict_copies", func(t *testing.T) {
+		dir := t.TempDir()
+		files := []string{"chores.md", "chores.sync-conflict-20260203-101530-ABCDEFG.md", "chores (Bob's conflicted copy 2026-02-03).md", "chores-notes.md"}
+		for _, name := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte("2026-02-01 Trash\n"), 0644); err != nil {
+				t.Fatalf("failed to write test file: %v", err)
+			}
+		}
+
+		result, err := ParseFile(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(result.Sources) != 2 || len(result.Completions) != 2 {
+			t.Errorf("conflict copies should not be read, got sources %v", result.Sources)
+		}
+
+		copies, err := ConflictCopies(filepath.Join(dir, "chores.md"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{filepath.Join(dir, files[2]), filepath.Join(dir, files[1])}
+		if strings.Join(copies, "\n") != strings.Join(want, "\n") {
+			t.Errorf("ConflictCopies = %v, want %v", copies, want)
+		}
+	})
+}
+
+func TestParse_longLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "chores.md")
+	content := "## Trash\n> 2d\n\n" + strings.Repeat("x", 100*1024) + "\n\n2026-02-01 Trash\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write test file: %v", err)
+	}
+	result, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Completions) != 1 || result.Completions[0].Line != 6 {
+		t.Errorf("completions = %+v, want one on line 6", result.Completions)
+	}
+}
+
+// largeLog returns a chores file of 20 chores and a log of the given number
+// of entries, one to three a day.
+func largeLog(entries int) string {
+	var sb strings.Builder
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&sb, "## Chore %d\n> %dd 15m grace 1d\n\nSome notes on chore %d.\n\n", i, i%7+1, i)
+	}
+	sb.WriteString("# Completion Log\n\n")
+	day := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < entries; i++ {
+		if i%2 == 0 {
+			day = day.AddDate(0, 0, 1)
+		}
+		fmt.Fprintf(&sb, "%s Chore %d @bob\n", day.Format("2006-01-02"), i%20)
+	}
+	return sb.String()
+}
+
+func BenchmarkParse(b *testing.B) {
+	content := largeLog(100000)
+	b.SetBytes(int64(len(content)))
+	for b.Loop() {
+		if _, err := Parse(content); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkParseFile(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "chores.md")
+	if err := os.WriteFile(path, []byte(largeLog(100000)), 0644); err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		if _, err := ParseFile(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func FuzzMatchCompletion(f *testing.F) {
+	for _, line := range []string{
+		"2026-02-01 Trash",
+		"2026-02-01 18:30 Take Out Trash @bob # late",
+		"2026-02-01 Bathroom [steps 1, 3] [archived 4] @bob#x y",
+		"2026-02-01 12:30",
+		"2026-02-01   ",
+		"2026-02-01 Trash [steps 1,]",
+		"2026-02-01 Trash [steps 1] room",
+		"2026-02-01 Trash @ann @bob",
+		"2026-02-01 Trash #",
+		"2026-02-01\tTrash\t@bob\t",
+		"2026-13-45 Trash",
+		"not an entry",
+	} {
+		f.Add(line)
+	}
+	f.Fuzz(func(t *testing.T, line string) {
+		want := completionRegex.FindStringSubmatch(line)
+		got := matchCompletion(line)
+		if strings.Join(got, "|") != strings.Join(want, "|") || (got == nil) != (want == nil) {
+			t.Errorf("matchCompletion(%q) = %q, want %q", line, got, want)
+		}
+	})
 }
@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"math"
 	"sort"
 	"strings"
 	"time"
@@ -12,9 +13,15 @@ type Status int
 
 const (
 	StatusOverdue Status = iota
+	StatusGrace          // Past due but still within the chore's grace window
 	StatusDueToday
 	StatusUpcoming
 	StatusClear
+	StatusDormant  // Outside the chore's active season
+	StatusFinished // One-off or limited-run chore that is complete or expired
+	StatusPaused   // Paused by the user
+	StatusArchived // Archived by the user
+	StatusWaiting  // Follow-up chore whose trigger has not happened yet
 )
 
 type ChoreStatus struct {
@@ -23,20 +30,340 @@ type ChoreStatus struct {
 	DaysOverdue int
 	DaysUntil   int
 	LastDone    *time.Time
+	Eligible    bool // Inside the window of a range frequency, before it ends
+
+	// Sub-daily chores are scheduled in minutes rather than whole days.
+	MinutesOverdue int
+	MinutesUntil   int
+
+	// Quota chores count completions in the current calendar period.
+	QuotaDone int
+	DaysLeft  int // Days left in the period, including today
+
+	// Usage chores track usage since the last completion. DaysUntil and
+	// DaysOverdue are estimated from the usage rate, or from the time
+	// fallback when that is sooner.
+	UsageSince int
+	NoEstimate bool // No usage rate and no time fallback to estimate a due date
+
+	// Checklist steps done since the last full completion, in order.
+	StepsDone []int
 }
 
 const NeverDoneSentinel = 999999
 
+const minutesPerDay = 24 * 60
+
 func DaysBetween(from, to time.Time) int {
 	fromUTC := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
 	toUTC := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
 	return int(toUTC.Sub(fromUTC).Hours() / 24)
 }
 
+// seasonStart returns the first day of the active season containing now.
+func seasonStart(chore model.Chore, now time.Time) time.Time {
+	year := now.Year()
+	if chore.ActiveFrom > now.Month() {
+		year--
+	}
+	return time.Date(year, chore.ActiveFrom, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// elapsedDays returns the days from the last completion to now. For seasonal
+// chores last done before the current season, the dormant months are skipped
+// so the chore falls due on the first day of the season.
+func elapsedDays(chore model.Chore, last, now time.Time) int {
+	if chore.ActiveFrom == 0 {
+		return DaysBetween(last, now)
+	}
+	start := seasonStart(chore, now)
+	if DaysBetween(last, start) > chore.DueDays() {
+		return chore.DueDays() + DaysBetween(start, now)
+	}
+	return DaysBetween(last, now)
+}
+
+// finished reports whether a one-off or limited-run chore is complete or
+// expired and should no longer be scheduled.
+func finished(chore model.Chore, count int, now time.Time) bool {
+	if chore.Once && count > 0 {
+		return true
+	}
+	if chore.MaxCount > 0 && count >= chore.MaxCount {
+		return true
+	}
+	return !chore.Until.IsZero() && DaysBetween(chore.Until, now) > 0
+}
+
+// classify sets the status of cs from the days elapsed since the reference
+// date and the number of days after it at which the chore is due.
+func classify(cs *ChoreStatus, daysSince, freq int) {
+	chore := cs.Chore
+
+	if daysSince > freq+chore.GraceDays {
+		cs.Status = StatusOverdue
+		cs.DaysOverdue = daysSince - freq
+	} else if daysSince > freq {
+		cs.Status = StatusGrace
+		cs.DaysOverdue = daysSince - freq
+	} else if daysSince == freq {
+		cs.Status = StatusDueToday
+	} else if chore.FrequencyMax > 0 && daysSince >= chore.FrequencyDays {
+		cs.Status = StatusUpcoming
+		cs.DaysUntil = freq - daysSince
+		cs.Eligible = true
+	} else if chore.FrequencyMax > 0 {
+		cs.Status = StatusClear
+		cs.DaysUntil = freq - daysSince
+	} else {
+		daysUntil := freq - daysSince
+		if daysUntil <= 7 {
+			cs.Status = StatusUpcoming
+			cs.DaysUntil = daysUntil
+		} else {
+			cs.Status = StatusClear
+			cs.DaysUntil = daysUntil
+		}
+	}
+}
+
+// classifyTime sets the status of a sub-daily chore from the time elapsed
+// since its last completion rather than from whole days.
+func classifyTime(cs *ChoreStatus, last, now time.Time) {
+	chore := cs.Chore
+
+	// Log entries carry no time zone, so compare against now's wall clock.
+	wall := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), now.Minute(), 0, 0, time.UTC)
+	due := last.Add(time.Duration(chore.FrequencyMins) * time.Minute)
+	late := int(wall.Sub(due).Minutes())
+
+	if late > chore.GraceDays*minutesPerDay {
+		cs.Status = StatusOverdue
+		cs.MinutesOverdue = late
+		cs.DaysOverdue = late / minutesPerDay
+	} else if late > 0 {
+		cs.Status = StatusGrace
+		cs.MinutesOverdue = late
+		cs.DaysOverdue = late / minutesPerDay
+	} else if DaysBetween(wall, due) == 0 {
+		cs.Status = StatusDueToday
+		cs.MinutesUntil = -late
+	} else {
+		cs.Status = StatusUpcoming
+		cs.MinutesUntil = -late
+		cs.DaysUntil = DaysBetween(wall, due)
+	}
+}
+
+// quotaPeriod returns the calendar period (week starting Monday, month or
+// year) containing now, as [start, end).
+func quotaPeriod(unit string, now time.Time) (start, end time.Time) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch unit {
+	case "w":
+		start = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		end = start.AddDate(0, 0, 7)
+	case "m":
+		start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, 0)
+	default:
+		start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, 0)
+	}
+	return start, end
+}
+
+// classifyQuota sets the status of a quota chore from the completions in the
+// current period. The chore is due today once the remaining completions need
+// one a day to fit, and overdue once they no longer fit at all.
+func classifyQuota(cs *ChoreStatus, dates []time.Time, now time.Time) {
+	chore := cs.Chore
+	start, end := quotaPeriod(chore.QuotaPeriod, now)
+
+	for _, d := range dates {
+		if !d.Before(start) && d.Before(end) {
+			cs.QuotaDone++
+		}
+	}
+	cs.DaysLeft = DaysBetween(now, end)
+
+	remaining := chore.QuotaCount - cs.QuotaDone
+	if remaining <= 0 {
+		cs.Status = StatusClear
+		cs.DaysUntil = cs.DaysLeft
+	} else if remaining > cs.DaysLeft {
+		cs.Status = StatusOverdue
+		cs.DaysOverdue = remaining - cs.DaysLeft
+	} else if remaining == cs.DaysLeft {
+		cs.Status = StatusDueToday
+	} else {
+		cs.Status = StatusUpcoming
+		cs.DaysUntil = cs.DaysLeft - remaining
+	}
+}
+
+// usageSince returns the usage accumulated since the last completion and the
+// daily usage rate over that span. Meter readings take precedence over
+// increments when a chore has any.
+func usageSince(usages []model.Usage, last, now time.Time) (used int, rate float64) {
+	var readings []model.Usage
+	for _, u := range usages {
+		if u.Reading {
+			readings = append(readings, u)
+		}
+	}
+
+	if len(readings) > 0 {
+		sort.SliceStable(readings, func(i, j int) bool { return readings[i].Date.Before(readings[j].Date) })
+		baseline := readings[0]
+		for _, r := range readings {
+			if DaysBetween(r.Date, last) >= 0 {
+				baseline = r
+			}
+		}
+		current := readings[len(readings)-1]
+		used = current.Amount - baseline.Amount
+		if span := DaysBetween(baseline.Date, current.Date); span > 0 {
+			rate = float64(used) / float64(span)
+		}
+		return used, rate
+	}
+
+	for _, u := range usages {
+		if DaysBetween(last, u.Date) > 0 {
+			used += u.Amount
+		}
+	}
+	span := DaysBetween(last, now)
+	if span < 1 {
+		span = 1
+	}
+	return used, float64(used) / float64(span)
+}
+
+// classifyUsage sets the status of a usage-based chore by estimating when the
+// usage limit will be reached, or when the time fallback expires if sooner.
+func classifyUsage(cs *ChoreStatus, usages []model.Usage, last, now time.Time) {
+	chore := cs.Chore
+	used, rate := usageSince(usages, last, now)
+	cs.UsageSince = used
+
+	estimated := false
+	var daysUntil int
+	if used >= chore.UsageLimit {
+		estimated = true
+		if rate > 0 {
+			daysUntil = -int(float64(used-chore.UsageLimit) / rate)
+		}
+	} else if rate > 0 {
+		estimated = true
+		daysUntil = int(math.Ceil(float64(chore.UsageLimit-used) / rate))
+	}
+
+	if chore.FrequencyDays > 0 {
+		fallback := chore.FrequencyDays - DaysBetween(last, now)
+		if !estimated || fallback < daysUntil {
+			estimated = true
+			daysUntil = fallback
+		}
+	}
+
+	if !estimated {
+		cs.Status = StatusClear
+		cs.NoEstimate = true
+		return
+	}
+	classify(cs, -daysUntil, 0)
+}
+
+// triggeredBy returns the completion date of the chore's "after:" trigger if
+// it happened after the chore was last done.
+func triggeredBy(chore model.Chore, completionMap map[string]time.Time, lastDone *time.Time) (time.Time, bool) {
+	if chore.AfterChore == "" {
+		return time.Time{}, false
+	}
+	trigger, ok := completionMap[strings.ToLower(chore.AfterChore)]
+	if !ok {
+		return time.Time{}, false
+	}
+	if lastDone != nil && DaysBetween(*lastDone, trigger) <= 0 {
+		return time.Time{}, false
+	}
+	return trigger, true
+}
+
+// mergeSteps replaces partial completions of checklist chores by the full
+// completions they add up to. Steps recorded since the last full completion
+// count as a full completion on the day the last missing step is done; until
+// then they are only progress, returned per lowercased chore name. Partial
+// entries for chores without a checklist are dropped.
+func mergeSteps(chores []model.Chore, completions []model.Completion) ([]model.Completion, map[string][]int) {
+	stepCounts := make(map[string]int)
+	for _, chore := range chores {
+		stepCounts[strings.ToLower(chore.Name)] = len(chore.Steps)
+	}
+
+	sorted := append([]model.Completion(nil), completions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date.Before(sorted[j].Date) })
+
+	var merged []model.Completion
+	done := make(map[string]map[int]bool)
+	for _, c := range sorted {
+		key := strings.ToLower(c.ChoreName)
+		if c.Steps == nil {
+			delete(done, key)
+			merged = append(merged, c)
+			continue
+		}
+
+		if done[key] == nil {
+			done[key] = make(map[int]bool)
+		}
+		for _, step := range c.Steps {
+			if step >= 1 && step <= stepCounts[key] {
+				done[key][step] = true
+			}
+		}
+		if count := stepCounts[key]; count > 0 && len(done[key]) == count {
+			delete(done, key)
+			full := c
+			full.Steps = nil
+			merged = append(merged, full)
+		}
+	}
+
+	progress := make(map[string][]int)
+	for key, steps := range done {
+		for step := range steps {
+			progress[key] = append(progress[key], step)
+		}
+		sort.Ints(progress[key])
+	}
+	return merged, progress
+}
+
 func Calculate(chores []model.Chore, completions []model.Completion, now time.Time) []ChoreStatus {
+	return CalculateWithUsage(chores, completions, nil, now)
+}
+
+// CalculateWithUsage is Calculate for files that also log usage, which
+// usage-based chores need to estimate their due dates.
+func CalculateWithUsage(chores []model.Chore, completions []model.Completion, usages []model.Usage, now time.Time) []ChoreStatus {
+	usageMap := make(map[string][]model.Usage)
+	for _, u := range usages {
+		key := strings.ToLower(u.ChoreName)
+		usageMap[key] = append(usageMap[key], u)
+	}
+
+	completions, progress := mergeSteps(chores, completions)
+
 	completionMap := make(map[string]time.Time)
+	countMap := make(map[string]int)
+	datesMap := make(map[string][]time.Time)
 	for _, c := range completions {
 		key := strings.ToLower(c.ChoreName)
+		countMap[key] += max(c.Archived, 1)
+		datesMap[key] = append(datesMap[key], c.Date)
 		if existing, ok := completionMap[key]; !ok || c.Date.After(existing) {
 			completionMap[key] = c.Date
 		}
@@ -46,35 +373,40 @@ func Calculate(chores []model.Chore, completions []model.Completion, now time.Ti
 
 	for _, chore := range chores {
 		key := strings.ToLower(chore.Name)
-		cs := ChoreStatus{Chore: chore}
+		cs := ChoreStatus{Chore: chore, StepsDone: progress[key]}
 
 		lastDone, hasCompletion := completionMap[key]
 		if hasCompletion {
 			cs.LastDone = &lastDone
 		}
 
-		if !hasCompletion {
+		if chore.State == model.StatePaused {
+			cs.Status = StatusPaused
+		} else if chore.State == model.StateArchived {
+			cs.Status = StatusArchived
+		} else if finished(chore, countMap[key], now) {
+			cs.Status = StatusFinished
+		} else if !chore.ActiveIn(now.Month()) {
+			cs.Status = StatusDormant
+		} else if trigger, ok := triggeredBy(chore, completionMap, cs.LastDone); ok {
+			classify(&cs, DaysBetween(trigger, now), chore.AfterWithinDays)
+		} else if !chore.HasSchedule() {
+			cs.Status = StatusWaiting
+		} else if chore.Once && chore.Deadline.IsZero() {
+			cs.Status = StatusDueToday
+		} else if chore.Once {
+			classify(&cs, DaysBetween(chore.Deadline, now), 0)
+		} else if chore.QuotaCount > 0 {
+			classifyQuota(&cs, datesMap[key], now)
+		} else if !hasCompletion {
 			cs.Status = StatusOverdue
 			cs.DaysOverdue = NeverDoneSentinel
+		} else if chore.UsageLimit > 0 {
+			classifyUsage(&cs, usageMap[key], lastDone, now)
+		} else if chore.FrequencyMins > 0 {
+			classifyTime(&cs, lastDone, now)
 		} else {
-			daysSince := DaysBetween(lastDone, now)
-			freq := chore.FrequencyDays
-
-			if daysSince > freq {
-				cs.Status = StatusOverdue
-				cs.DaysOverdue = daysSince - freq
-			} else if daysSince == freq {
-				cs.Status = StatusDueToday
-			} else {
-				daysUntil := freq - daysSince
-				if daysUntil <= 7 {
-					cs.Status = StatusUpcoming
-					cs.DaysUntil = daysUntil
-				} else {
-					cs.Status = StatusClear
-					cs.DaysUntil = daysUntil
-				}
-			}
+			classify(&cs, elapsedDays(chore, lastDone, now), chore.DueDays())
 		}
 
 		results = append(results, cs)
@@ -92,6 +424,13 @@ func SortByUrgency(statuses []ChoreStatus) {
 		}
 
 		switch si.Status {
+		case StatusGrace:
+			if si.DaysOverdue != sj.DaysOverdue {
+				return si.DaysOverdue > sj.DaysOverdue
+			}
+			if si.MinutesOverdue != sj.MinutesOverdue {
+				return si.MinutesOverdue > sj.MinutesOverdue
+			}
 		case StatusOverdue:
 			if si.DaysOverdue == NeverDoneSentinel && sj.DaysOverdue != NeverDoneSentinel {
 				return false
@@ -102,10 +441,23 @@ func SortByUrgency(statuses []ChoreStatus) {
 			if si.DaysOverdue != sj.DaysOverdue {
 				return si.DaysOverdue > sj.DaysOverdue
 			}
+			if si.MinutesOverdue != sj.MinutesOverdue {
+				return si.MinutesOverdue > sj.MinutesOverdue
+			}
+		case StatusDueToday:
+			if si.MinutesUntil != sj.MinutesUntil {
+				return si.MinutesUntil < sj.MinutesUntil
+			}
 		case StatusUpcoming, StatusClear:
 			if si.DaysUntil != sj.DaysUntil {
 				return si.DaysUntil < sj.DaysUntil
 			}
+			if si.MinutesUntil != sj.MinutesUntil {
+				return si.MinutesUntil < sj.MinutesUntil
+			}
+			if si.Eligible != sj.Eligible {
+				return si.Eligible
+			}
 		}
 
 		return strings.ToLower(si.Chore.Name) < strings.ToLower(sj.Chore.Name)
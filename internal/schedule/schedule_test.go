@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -107,6 +108,370 @@ func TestCalculate(t *testing.T) {
 		}
 	})
 
+	t.Run("grace", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Test", FrequencyDays: 7, GraceDays: 2}}
+
+		tests := []struct {
+			last        time.Time
+			wantStatus  Status
+			wantOverdue int
+		}{
+			{date(2026, 2, 3), StatusDueToday, 0},
+			{date(2026, 2, 2), StatusGrace, 1},
+			{date(2026, 2, 1), StatusGrace, 2},
+			{date(2026, 1, 31), StatusOverdue, 3},
+		}
+		for _, tt := range tests {
+			completions := []model.Completion{{ChoreName: "Test", Date: tt.last}}
+			cs := Calculate(chores, completions, now)[0]
+			if cs.Status != tt.wantStatus {
+				t.Errorf("last %v: status = %v, want %v", tt.last, cs.Status, tt.wantStatus)
+			}
+			if cs.DaysOverdue != tt.wantOverdue {
+				t.Errorf("last %v: DaysOverdue = %d, want %d", tt.last, cs.DaysOverdue, tt.wantOverdue)
+			}
+		}
+	})
+
+	t.Run("range", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Test", FrequencyDays: 5, FrequencyMax: 9}}
+
+		tests := []struct {
+			last         time.Time
+			wantStatus   Status
+			wantUntil    int
+			wantEligible bool
+		}{
+			{date(2026, 2, 8), StatusClear, 7, false},
+			{date(2026, 2, 5), StatusUpcoming, 4, true},
+			{date(2026, 2, 2), StatusUpcoming, 1, true},
+			{date(2026, 2, 1), StatusDueToday, 0, false},
+			{date(2026, 1, 31), StatusOverdue, 0, false},
+		}
+		for _, tt := range tests {
+			completions := []model.Completion{{ChoreName: "Test", Date: tt.last}}
+			cs := Calculate(chores, completions, now)[0]
+			if cs.Status != tt.wantStatus {
+				t.Errorf("last %v: status = %v, want %v", tt.last, cs.Status, tt.wantStatus)
+			}
+			if cs.DaysUntil != tt.wantUntil {
+				t.Errorf("last %v: DaysUntil = %d, want %d", tt.last, cs.DaysUntil, tt.wantUntil)
+			}
+			if cs.Eligible != tt.wantEligible {
+				t.Errorf("last %v: Eligible = %v, want %v", tt.last, cs.Eligible, tt.wantEligible)
+			}
+		}
+	})
+
+	t.Run("dormant_out_of_season", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Mow", FrequencyDays: 7, ActiveFrom: time.April, ActiveTo: time.October}}
+		completions := []model.Completion{{ChoreName: "Mow", Date: date(2025, 10, 20)}}
+
+		cs := Calculate(chores, completions, now)[0]
+		if cs.Status != StatusDormant {
+			t.Errorf("status = %v, want StatusDormant", cs.Status)
+		}
+	})
+
+	t.Run("season_start_due", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Mow", FrequencyDays: 7, ActiveFrom: time.April, ActiveTo: time.October}}
+		completions := []model.Completion{{ChoreName: "Mow", Date: date(2025, 10, 20)}}
+
+		cs := Calculate(chores, completions, date(2026, 4, 1))[0]
+		if cs.Status != StatusDueToday {
+			t.Errorf("first day of season: status = %v, want StatusDueToday", cs.Status)
+		}
+
+		cs = Calculate(chores, completions, date(2026, 4, 4))[0]
+		if cs.Status != StatusOverdue || cs.DaysOverdue != 3 {
+			t.Errorf("fourth day of season: status = %v, DaysOverdue = %d, want overdue by 3", cs.Status, cs.DaysOverdue)
+		}
+	})
+
+	t.Run("wrapping_season", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Snow", FrequencyDays: 14, ActiveFrom: time.November, ActiveTo: time.March}}
+		completions := []model.Completion{{ChoreName: "Snow", Date: date(2026, 2, 1)}}
+
+		cs := Calculate(chores, completions, now)[0]
+		if cs.Status != StatusUpcoming || cs.DaysUntil != 5 {
+			t.Errorf("status = %v, DaysUntil = %d, want upcoming in 5", cs.Status, cs.DaysUntil)
+		}
+	})
+
+	t.Run("once", func(t *testing.T) {
+		chores := []model.Chore{
+			{Name: "No Deadline", Once: true},
+			{Name: "Deadline Soon", Once: true, Deadline: date(2026, 2, 13)},
+			{Name: "Deadline Passed", Once: true, Deadline: date(2026, 2, 8)},
+			{Name: "Done", Once: true, Deadline: date(2026, 2, 8)},
+		}
+		completions := []model.Completion{{ChoreName: "Done", Date: date(2026, 2, 1)}}
+
+		results := Calculate(chores, completions, now)
+		want := []struct {
+			status  Status
+			until   int
+			overdue int
+		}{
+			{StatusDueToday, 0, 0},
+			{StatusUpcoming, 3, 0},
+			{StatusOverdue, 0, 2},
+			{StatusFinished, 0, 0},
+		}
+		for i, w := range want {
+			cs := results[i]
+			if cs.Status != w.status || cs.DaysUntil != w.until || cs.DaysOverdue != w.overdue {
+				t.Errorf("%s: status/until/overdue = %v/%d/%d, want %v/%d/%d",
+					cs.Chore.Name, cs.Status, cs.DaysUntil, cs.DaysOverdue, w.status, w.until, w.overdue)
+			}
+		}
+	})
+
+	t.Run("limited_run", func(t *testing.T) {
+		chores := []model.Chore{
+			{Name: "Count Left", FrequencyDays: 1, MaxCount: 3},
+			{Name: "Count Done", FrequencyDays: 1, MaxCount: 2},
+			{Name: "Until Today", FrequencyDays: 1, Until: date(2026, 2, 10)},
+			{Name: "Until Passed", FrequencyDays: 1, Until: date(2026, 2, 9)},
+		}
+		var completions []model.Completion
+		for _, name := range []string{"Count Left", "Count Done", "Until Today", "Until Passed"} {
+			completions = append(completions,
+				model.Completion{ChoreName: name, Date: date(2026, 2, 8)},
+				model.Completion{ChoreName: name, Date: date(2026, 2, 9)})
+		}
+
+		results := Calculate(chores, completions, now)
+		want := []Status{StatusDueToday, StatusFinished, StatusDueToday, StatusFinished}
+		for i, w := range want {
+			if results[i].Status != w {
+				t.Errorf("%s: status = %v, want %v", results[i].Chore.Name, results[i].Status, w)
+			}
+		}
+	})
+
+	t.Run("archive_summary", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Count", FrequencyDays: 7, MaxCount: 5}}
+		completions := []model.Completion{
+			{ChoreName: "Count", Date: date(2026, 1, 20), Archived: 4},
+			{ChoreName: "Count", Date: date(2026, 2, 3)},
+		}
+
+		if cs := Calculate(chores, completions, now)[0]; cs.Status != StatusFinished {
+			t.Errorf("status = %v, want StatusFinished", cs.Status)
+		}
+	})
+
+	t.Run("paused_and_archived", func(t *testing.T) {
+		chores := []model.Chore{
+			{Name: "Paused", FrequencyDays: 7, State: model.StatePaused},
+			{Name: "Archived", FrequencyDays: 7, State: model.StateArchived},
+		}
+
+		results := Calculate(chores, nil, now)
+		if results[0].Status != StatusPaused {
+			t.Errorf("status = %v, want StatusPaused", results[0].Status)
+		}
+		if results[1].Status != StatusArchived {
+			t.Errorf("status = %v, want StatusArchived", results[1].Status)
+		}
+	})
+
+	t.Run("sub_daily", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Feed", FrequencyMins: 720}}
+		noon := time.Date(2026, 2, 10, 12, 0, 0, 0, time.UTC)
+
+		tests := []struct {
+			last        time.Time
+			wantStatus  Status
+			wantUntil   int
+			wantOverdue int
+		}{
+			{time.Date(2026, 2, 10, 3, 0, 0, 0, time.UTC), StatusDueToday, 180, 0},
+			{time.Date(2026, 2, 10, 11, 0, 0, 0, time.UTC), StatusDueToday, 660, 0},
+			{time.Date(2026, 2, 10, 12, 30, 0, 0, time.UTC), StatusUpcoming, 750, 0},
+			{time.Date(2026, 2, 9, 20, 0, 0, 0, time.UTC), StatusOverdue, 0, 240},
+		}
+		for _, tt := range tests {
+			completions := []model.Completion{{ChoreName: "Feed", Date: tt.last, HasTime: true}}
+			cs := Calculate(chores, completions, noon)[0]
+			if cs.Status != tt.wantStatus {
+				t.Errorf("last %v: status = %v, want %v", tt.last, cs.Status, tt.wantStatus)
+			}
+			if cs.MinutesUntil != tt.wantUntil {
+				t.Errorf("last %v: MinutesUntil = %d, want %d", tt.last, cs.MinutesUntil, tt.wantUntil)
+			}
+			if cs.MinutesOverdue != tt.wantOverdue {
+				t.Errorf("last %v: MinutesOverdue = %d, want %d", tt.last, cs.MinutesOverdue, tt.wantOverdue)
+			}
+		}
+	})
+
+	t.Run("quota", func(t *testing.T) {
+		// 2026-02-10 is a Tuesday: the week runs 2026-02-09 to 2026-02-15, 6 days left.
+		chore := model.Chore{Name: "Vacuum", QuotaCount: 3, QuotaPeriod: "w"}
+
+		tests := []struct {
+			name       string
+			done       []time.Time
+			quota      model.Chore
+			wantStatus Status
+			wantDone   int
+		}{
+			{"previous_week_ignored", []time.Time{date(2026, 2, 8)}, chore, StatusUpcoming, 0},
+			{"partial", []time.Time{date(2026, 2, 9)}, chore, StatusUpcoming, 1},
+			{"met", []time.Time{date(2026, 2, 9), date(2026, 2, 9), date(2026, 2, 10)}, chore, StatusClear, 3},
+			{"due_today", nil, model.Chore{Name: "Vacuum", QuotaCount: 6, QuotaPeriod: "w"}, StatusDueToday, 0},
+			{"behind", nil, model.Chore{Name: "Vacuum", QuotaCount: 8, QuotaPeriod: "w"}, StatusOverdue, 0},
+		}
+		for _, tt := range tests {
+			var completions []model.Completion
+			for _, d := range tt.done {
+				completions = append(completions, model.Completion{ChoreName: "Vacuum", Date: d})
+			}
+			cs := Calculate([]model.Chore{tt.quota}, completions, now)[0]
+			if cs.Status != tt.wantStatus {
+				t.Errorf("%s: status = %v, want %v", tt.name, cs.Status, tt.wantStatus)
+			}
+			if cs.QuotaDone != tt.wantDone {
+				t.Errorf("%s: QuotaDone = %d, want %d", tt.name, cs.QuotaDone, tt.wantDone)
+			}
+			if cs.DaysLeft != 6 {
+				t.Errorf("%s: DaysLeft = %d, want 6", tt.name, cs.DaysLeft)
+			}
+		}
+	})
+
+	t.Run("quota_month", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Water", QuotaCount: 2, QuotaPeriod: "m"}}
+		completions := []model.Completion{{ChoreName: "Water", Date: date(2026, 2, 1)}}
+
+		cs := Calculate(chores, completions, now)[0]
+		if cs.QuotaDone != 1 || cs.DaysLeft != 19 {
+			t.Errorf("QuotaDone/DaysLeft = %d/%d, want 1/19", cs.QuotaDone, cs.DaysLeft)
+		}
+	})
+
+	t.Run("usage_increments", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Filter", UsageLimit: 30}}
+		completions := []model.Completion{{ChoreName: "Filter", Date: date(2026, 2, 1)}}
+		usages := []model.Usage{
+			{ChoreName: "Filter", Date: date(2026, 1, 31), Amount: 50}, // before last completion
+			{ChoreName: "Filter", Date: date(2026, 2, 3), Amount: 9},
+			{ChoreName: "filter", Date: date(2026, 2, 8), Amount: 9},
+		}
+
+		// 18 used over 9 days: 2 per day, 12 left is 6 days.
+		cs := CalculateWithUsage(chores, completions, usages, now)[0]
+		if cs.UsageSince != 18 {
+			t.Errorf("UsageSince = %d, want 18", cs.UsageSince)
+		}
+		if cs.Status != StatusUpcoming || cs.DaysUntil != 6 {
+			t.Errorf("status = %v, DaysUntil = %d, want upcoming in 6", cs.Status, cs.DaysUntil)
+		}
+	})
+
+	t.Run("usage_readings", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Tires", UsageLimit: 8000}}
+		completions := []model.Completion{{ChoreName: "Tires", Date: date(2025, 6, 1)}}
+		usages := []model.Usage{
+			{ChoreName: "Tires", Date: date(2025, 6, 1), Amount: 40000, Reading: true},
+			{ChoreName: "Tires", Date: date(2026, 2, 6), Amount: 48500, Reading: true},
+		}
+
+		cs := CalculateWithUsage(chores, completions, usages, now)[0]
+		if cs.UsageSince != 8500 {
+			t.Errorf("UsageSince = %d, want 8500", cs.UsageSince)
+		}
+		if cs.Status != StatusOverdue {
+			t.Errorf("status = %v, want StatusOverdue", cs.Status)
+		}
+	})
+
+	t.Run("usage_time_fallback", func(t *testing.T) {
+		chores := []model.Chore{
+			{Name: "Descale", UsageLimit: 200, FrequencyDays: 7},
+			{Name: "No Rate", UsageLimit: 200},
+		}
+		completions := []model.Completion{
+			{ChoreName: "Descale", Date: date(2026, 2, 1)},
+			{ChoreName: "No Rate", Date: date(2026, 2, 1)},
+		}
+
+		results := Calculate(chores, completions, now)
+		if results[0].Status != StatusOverdue || results[0].DaysOverdue != 2 {
+			t.Errorf("fallback: status = %v, DaysOverdue = %d, want overdue by 2", results[0].Status, results[0].DaysOverdue)
+		}
+		if results[1].Status != StatusClear || !results[1].NoEstimate {
+			t.Errorf("no rate: status = %v, NoEstimate = %v, want clear without estimate", results[1].Status, results[1].NoEstimate)
+		}
+	})
+
+	t.Run("after", func(t *testing.T) {
+		chores := []model.Chore{
+			{Name: "Mow", FrequencyDays: 7},
+			{Name: "Deck", AfterChore: "mow", AfterWithinDays: 2},
+		}
+
+		tests := []struct {
+			name        string
+			completions []model.Completion
+			wantStatus  Status
+		}{
+			{"never_triggered", nil, StatusWaiting},
+			{"triggered", []model.Completion{{ChoreName: "Mow", Date: date(2026, 2, 9)}}, StatusUpcoming},
+			{"triggered_late", []model.Completion{{ChoreName: "Mow", Date: date(2026, 2, 5)}}, StatusOverdue},
+			{"done_since_trigger", []model.Completion{
+				{ChoreName: "Mow", Date: date(2026, 2, 5)},
+				{ChoreName: "Deck", Date: date(2026, 2, 5)},
+			}, StatusWaiting},
+			{"triggered_again", []model.Completion{
+				{ChoreName: "Mow", Date: date(2026, 2, 8)},
+				{ChoreName: "Deck", Date: date(2026, 2, 1)},
+			}, StatusDueToday},
+		}
+		for _, tt := range tests {
+			cs := Calculate(chores, tt.completions, now)[1]
+			if cs.Status != tt.wantStatus {
+				t.Errorf("%s: status = %v, want %v", tt.name, cs.Status, tt.wantStatus)
+			}
+		}
+	})
+
+	t.Run("steps", func(t *testing.T) {
+		chores := []model.Chore{{Name: "Bathroom", FrequencyDays: 7, Steps: []string{"Toilet", "Mirror", "Floor"}}}
+
+		tests := []struct {
+			name          string
+			completions   []model.Completion
+			wantStatus    Status
+			wantStepsDone []int
+		}{
+			{"partial_still_due", []model.Completion{
+				{ChoreName: "Bathroom", Date: date(2026, 2, 1)},
+				{ChoreName: "Bathroom", Date: date(2026, 2, 9), Steps: []int{3, 1}},
+			}, StatusOverdue, []int{1, 3}},
+			{"partials_add_up", []model.Completion{
+				{ChoreName: "Bathroom", Date: date(2026, 2, 1)},
+				{ChoreName: "Bathroom", Date: date(2026, 2, 8), Steps: []int{1, 3}},
+				{ChoreName: "Bathroom", Date: date(2026, 2, 9), Steps: []int{2}},
+			}, StatusUpcoming, nil},
+			{"full_resets_progress", []model.Completion{
+				{ChoreName: "Bathroom", Date: date(2026, 2, 2), Steps: []int{2}},
+				{ChoreName: "Bathroom", Date: date(2026, 2, 9)},
+			}, StatusUpcoming, nil},
+		}
+		for _, tt := range tests {
+			cs := Calculate(chores, tt.completions, now)[0]
+			if cs.Status != tt.wantStatus {
+				t.Errorf("%s: status = %v, want %v", tt.name, cs.Status, tt.wantStatus)
+			}
+			if fmt.Sprint(cs.StepsDone) != fmt.Sprint(tt.wantStepsDone) {
+				t.Errorf("%s: steps done = %v, want %v", tt.name, cs.StepsDone, tt.wantStepsDone)
+			}
+		}
+	})
+
 	t.Run("case_insensitive_match", func(t *testing.T) {
 		chores := []model.Chore{{Name: "Kitchen Clean", FrequencyDays: 7}}
 		completions := []model.Completion{{ChoreName: "kitchen clean", Date: date(2026, 2, 9)}}
@@ -149,17 +514,49 @@ func TestSortByUrgency(t *testing.T) {
 		}
 	})
 
+	t.Run("eligible_first_on_tie", func(t *testing.T) {
+		statuses := []ChoreStatus{
+			{Chore: model.Chore{Name: "Alpha"}, Status: StatusUpcoming, DaysUntil: 3},
+			{Chore: model.Chore{Name: "Window"}, Status: StatusUpcoming, DaysUntil: 3, Eligible: true},
+		}
+
+		SortByUrgency(statuses)
+
+		if statuses[0].Chore.Name != "Window" {
+			t.Errorf("expected eligible chore first, got %s", statuses[0].Chore.Name)
+		}
+	})
+
+	t.Run("sub_daily_minutes", func(t *testing.T) {
+		statuses := []ChoreStatus{
+			{Chore: model.Chore{Name: "Alpha"}, Status: StatusDueToday, MinutesUntil: 300},
+			{Chore: model.Chore{Name: "Beta"}, Status: StatusDueToday, MinutesUntil: 60},
+			{Chore: model.Chore{Name: "Gamma"}, Status: StatusOverdue, MinutesOverdue: 30},
+			{Chore: model.Chore{Name: "Delta"}, Status: StatusOverdue, MinutesOverdue: 90},
+		}
+
+		SortByUrgency(statuses)
+
+		expected := []string{"Delta", "Gamma", "Beta", "Alpha"}
+		for i, name := range expected {
+			if statuses[i].Chore.Name != name {
+				t.Errorf("position %d: got %s, want %s", i, statuses[i].Chore.Name, name)
+			}
+		}
+	})
+
 	t.Run("status_order", func(t *testing.T) {
 		statuses := []ChoreStatus{
 			{Chore: model.Chore{Name: "Clear"}, Status: StatusClear, DaysUntil: 10},
 			{Chore: model.Chore{Name: "Upcoming"}, Status: StatusUpcoming, DaysUntil: 2},
 			{Chore: model.Chore{Name: "DueToday"}, Status: StatusDueToday},
+			{Chore: model.Chore{Name: "Grace"}, Status: StatusGrace, DaysOverdue: 1},
 			{Chore: model.Chore{Name: "Overdue"}, Status: StatusOverdue, DaysOverdue: 1},
 		}
 
 		SortByUrgency(statuses)
 
-		expected := []string{"Overdue", "DueToday", "Upcoming", "Clear"}
+		expected := []string{"Overdue", "Grace", "DueToday", "Upcoming", "Clear"}
 		for i, name := range expected {
 			if statuses[i].Chore.Name != name {
 				t.Errorf("position %d: got %s, want %s", i, statuses[i].Chore.Name, name)
@@ -0,0 +1,72 @@
+package schedule
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kusha/chores-md/internal/model"
+)
+
+// ChoreStats summarizes how punctually a chore has been completed. Each
+// completion after the first is classified by the gap since the previous one;
+// quota chores and usage chores without a time fallback are only counted.
+// Checklist steps count once all of them are done. An archive summary counts
+// as all the completions it stands for, but only its last one is classified.
+type ChoreStats struct {
+	Chore   model.Chore
+	Total   int // Number of completions
+	OnTime  int // Gap within the frequency
+	InGrace int // Gap past the frequency but within the grace window
+	Late    int // Gap past frequency plus grace
+}
+
+func Stats(chores []model.Chore, completions []model.Completion) []ChoreStats {
+	completions, _ = mergeSteps(chores, completions)
+
+	dates := make(map[string][]time.Time)
+	archived := make(map[string]int)
+	for _, c := range completions {
+		key := strings.ToLower(c.ChoreName)
+		dates[key] = append(dates[key], c.Date)
+		if c.Archived > 1 {
+			archived[key] += c.Archived - 1
+		}
+	}
+
+	var results []ChoreStats
+
+	for _, chore := range chores {
+		key := strings.ToLower(chore.Name)
+		done := dates[key]
+		sort.Slice(done, func(i, j int) bool { return done[i].Before(done[j]) })
+
+		st := ChoreStats{Chore: chore, Total: len(done) + archived[key]}
+		due, grace := chore.DueDays(), chore.GraceDays
+		if chore.FrequencyMins > 0 {
+			due, grace = chore.FrequencyMins, chore.GraceDays*minutesPerDay
+		}
+
+		scheduled := chore.QuotaCount == 0 && (due > 0 || chore.FrequencyMins > 0)
+		for i := 1; i < len(done) && scheduled; i++ {
+			var gap int
+			if chore.FrequencyMins > 0 {
+				gap = int(done[i].Sub(done[i-1]).Minutes())
+			} else {
+				gap = elapsedDays(chore, done[i-1], done[i])
+			}
+			switch {
+			case gap <= due:
+				st.OnTime++
+			case gap <= due+grace:
+				st.InGrace++
+			default:
+				st.Late++
+			}
+		}
+
+		results = append(results, st)
+	}
+
+	return results
+}
@@ -0,0 +1,44 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kusha/chores-md/internal/model"
+)
+
+func TestStats(t *testing.T) {
+	chores := []model.Chore{{Name: "Test", FrequencyDays: 7, GraceDays: 2}}
+	completions := []model.Completion{
+		{ChoreName: "Test", Date: date(2026, 1, 1)},
+		{ChoreName: "test", Date: date(2026, 1, 8)},  // 7 days: on time
+		{ChoreName: "Test", Date: date(2026, 1, 30)}, // 13 days: late
+		{ChoreName: "Test", Date: date(2026, 1, 17)}, // 9 days: in grace (out of order in file)
+	}
+
+	results := Stats(chores, completions)
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+
+	st := results[0]
+	if st.Total != 4 {
+		t.Errorf("Total = %d, want 4", st.Total)
+	}
+	if st.OnTime != 1 || st.InGrace != 1 || st.Late != 1 {
+		t.Errorf("OnTime/InGrace/Late = %d/%d/%d, want 1/1/1", st.OnTime, st.InGrace, st.Late)
+	}
+}
+
+func TestStats_season(t *testing.T) {
+	chores := []model.Chore{{Name: "Mow", FrequencyDays: 7, ActiveFrom: time.April, ActiveTo: time.October}}
+	completions := []model.Completion{
+		{ChoreName: "Mow", Date: date(2025, 10, 28)},
+		{ChoreName: "Mow", Date: date(2026, 4, 1)}, // first day of the next season
+	}
+
+	st := Stats(chores, completions)[0]
+	if st.OnTime != 1 || st.Late != 0 {
+		t.Errorf("OnTime/Late = %d/%d, want 1/0 (dormant months skipped)", st.OnTime, st.Late)
+	}
+}